@@ -0,0 +1,339 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+)
+
+// --- MULTI-SELECT ---
+// Marks are keyed ns/name and keep the PodInfo they were made with, so a
+// marked pod stays marked when it drops out of the current filter. With
+// pods marked, delete, restart, evict, tail and label act on the marks.
+func (m *model) toggleMark() {
+	if len(m.filteredPods) == 0 {
+		return
+	}
+	p := m.filteredPods[m.cursor]
+	key := p.Namespace + "/" + p.Name
+	if _, ok := m.marked[key]; ok {
+		delete(m.marked, key)
+	} else {
+		m.marked[key] = p
+	}
+	if m.cursor < len(m.filteredPods)-1 {
+		m.cursor++
+	}
+}
+
+// markPods marks every pod given, or unmarks them if all already are.
+func (m *model) markPods(pods []PodInfo) {
+	all := len(pods) > 0
+	for _, p := range pods {
+		if _, ok := m.marked[p.Namespace+"/"+p.Name]; !ok {
+			all = false
+			break
+		}
+	}
+	for _, p := range pods {
+		if all {
+			delete(m.marked, p.Namespace+"/"+p.Name)
+		} else {
+			m.marked[p.Namespace+"/"+p.Name] = p
+		}
+	}
+	m.msg = fmt.Sprintf("%d pods marked", len(m.marked))
+}
+
+// pruneMarks drops marks on pods that no longer exist.
+func (m *model) pruneMarks() {
+	for key := range m.marked {
+		if !m.podExists(key) {
+			delete(m.marked, key)
+		}
+	}
+}
+
+// targets is what a bulk action applies to: the marked pods, or the one
+// under the cursor.
+func (m model) targets() []PodInfo {
+	var pods []PodInfo
+	for _, p := range m.marked {
+		pods = append(pods, p)
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+	if len(pods) == 0 && len(m.filteredPods) > 0 {
+		pods = append(pods, m.filteredPods[m.cursor])
+	}
+	return pods
+}
+
+// tailMarked tails every container of the marked pods in one stream.
+func (m *model) tailMarked() tea.Cmd {
+	set := make(map[string]bool, len(m.marked))
+	for key := range m.marked {
+		set[key] = true
+	}
+	m.selectedPod = nil
+	m.state = viewLogs
+	m.msg = fmt.Sprintf("Tailing %d marked pods", len(set))
+	return m.startTail("", fmt.Sprintf("%d marked pods", len(set)), labels.Everything(), set)
+}
+
+// --- BULK ACTIONS ---
+type bulkPlan struct {
+	action    string // "delete", "restart", "evict" or "label"
+	pods      []PodInfo
+	labels    map[string]*string // For "label"; nil removes the key
+	labelText string
+}
+
+type bulkResult struct {
+	pod  PodInfo
+	note string // What was done, e.g. "via Deployment/web"
+	err  error
+}
+
+type bulkDoneMsg struct {
+	action  string
+	results []bulkResult
+}
+
+func (m *model) openBulk(action string) {
+	pods := m.targets()
+	if len(pods) == 0 {
+		return
+	}
+	m.bulk = &bulkPlan{action: action, pods: pods}
+	m.deleteOpts = deleteOptions{grace: -1}
+	m.state = viewBulkConfirm
+}
+
+// parseLabelChanges reads kubectl label syntax: "key=value" sets a label
+// and "key-" removes it.
+func parseLabelChanges(s string) (map[string]*string, error) {
+	changes := make(map[string]*string)
+	for _, f := range strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' }) {
+		if key, ok := strings.CutSuffix(f, "-"); ok && !strings.Contains(f, "=") {
+			if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+				return nil, fmt.Errorf("invalid key %q: %s", key, errs[0])
+			}
+			changes[key] = nil
+			continue
+		}
+		key, value, ok := strings.Cut(f, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected key=value or key-, got %q", f)
+		}
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid key %q: %s", key, errs[0])
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid value %q: %s", value, errs[0])
+		}
+		changes[key] = &value
+	}
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("no label changes")
+	}
+	return changes, nil
+}
+
+func (m *model) submitLabels(value string) {
+	changes, err := parseLabelChanges(value)
+	if err != nil {
+		m.msg = fmt.Sprintf("Label failed: %v", err)
+		return
+	}
+	m.openBulk("label")
+	if m.bulk != nil {
+		m.bulk.labels, m.bulk.labelText = changes, strings.TrimSpace(value)
+	}
+}
+
+// runBulk applies the plan one pod at a time and reports each outcome.
+// raws holds the cached pod objects, which restart and evict consult.
+func runBulk(c *kubernetes.Clientset, plan bulkPlan, opts metav1.DeleteOptions, raws map[string]*corev1.Pod) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.TODO()
+		restarted := make(map[string]error) // A workload restarts once for all its pods
+		results := make([]bulkResult, 0, len(plan.pods))
+		for _, p := range plan.pods {
+			raw := raws[p.Namespace+"/"+p.Name]
+			r := bulkResult{pod: p}
+			switch plan.action {
+			case "delete":
+				r.err = c.CoreV1().Pods(p.Namespace).Delete(ctx, p.Name, opts)
+			case "evict":
+				r.err = evictPod(ctx, c, p.Namespace, p.Name, nil)
+				if apierrors.IsTooManyRequests(r.err) && raw != nil {
+					if pdb := blockingPDBs(c, raw); pdb != "" {
+						r.err = fmt.Errorf("blocked by PodDisruptionBudget %s", pdb)
+					}
+				}
+			case "label":
+				patch, _ := json.Marshal(map[string]interface{}{"metadata": map[string]interface{}{"labels": plan.labels}})
+				_, r.err = c.CoreV1().Pods(p.Namespace).Patch(ctx, p.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{FieldManager: "kube-pulse"})
+			case "restart":
+				rp, err := planRestart(c, p, raw)
+				switch {
+				case err != nil:
+					r.err = err
+				case rp.rollout:
+					key := rp.owner.Namespace + "/" + rp.owner.String()
+					if err, done := restarted[key]; done {
+						r.err, r.note = err, "via "+rp.owner.String()
+						break
+					}
+					_, r.err = patchRestartedAt(c, rp.owner)
+					restarted[key] = r.err
+					r.note = "rollout of " + rp.owner.String()
+				case rp.owner.Kind != "":
+					r.err = c.CoreV1().Pods(p.Namespace).Delete(ctx, p.Name, metav1.DeleteOptions{})
+					r.note = "deleted, recreated by " + rp.owner.String()
+				default:
+					r.err = fmt.Errorf("no controller to recreate it")
+				}
+			}
+			results = append(results, r)
+		}
+		return bulkDoneMsg{action: plan.action, results: results}
+	}
+}
+
+// finishBulk shows the summary and keeps only the failed pods marked, so
+// the action can be retried on them.
+func (m *model) finishBulk(msg bulkDoneMsg) {
+	failed := 0
+	for _, r := range msg.results {
+		key := r.pod.Namespace + "/" + r.pod.Name
+		if r.err != nil {
+			failed++
+			if len(msg.results) > 1 {
+				m.marked[key] = r.pod
+			}
+		} else {
+			delete(m.marked, key)
+		}
+	}
+	// Failures first, they are what needs reading
+	sort.SliceStable(msg.results, func(i, j int) bool { return msg.results[i].err != nil && msg.results[j].err == nil })
+	m.bulkResults = &msg
+	if m.state == viewList {
+		m.state = viewBulkResult
+	}
+	m.msg = fmt.Sprintf("%s: %d succeeded, %d failed", strings.ToUpper(msg.action[:1])+msg.action[1:], len(msg.results)-failed, failed)
+}
+
+func (m model) updateBulkConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		raws := make(map[string]*corev1.Pod, len(m.bulk.pods))
+		for _, p := range m.bulk.pods {
+			if raw, ok := m.watcher.getPod(p.Namespace + "/" + p.Name); ok {
+				raws[p.Namespace+"/"+p.Name] = raw
+			}
+		}
+		plan := *m.bulk
+		m.bulk = nil
+		m.state = viewList
+		m.msg = fmt.Sprintf("Running %s on %d pods...", plan.action, len(plan.pods))
+		return m, runBulk(m.client, plan, m.deleteOpts.apiOptions(), raws)
+	case "g":
+		if m.bulk.action == "delete" {
+			grace := ""
+			if m.deleteOpts.grace >= 0 {
+				grace = fmt.Sprint(m.deleteOpts.grace)
+			}
+			m.openPrompt("grace", "grace period seconds (empty for default)", grace)
+			return m, textinput.Blink
+		}
+	case "f":
+		if m.bulk.action == "delete" {
+			m.deleteOpts.force = !m.deleteOpts.force
+		}
+	case "n", "N", "esc", "q":
+		m.msg = fmt.Sprintf("%s cancelled.", strings.ToUpper(m.bulk.action[:1])+m.bulk.action[1:])
+		m.bulk = nil
+		m.state = viewList
+	}
+	return m, nil
+}
+
+func (m model) bulkConfirmView() string {
+	b := m.bulk
+	color := cOrange
+	var title, note string
+	switch b.action {
+	case "delete":
+		color, title, note = cRed, "[!] DELETE", m.deleteOpts.label()
+	case "evict":
+		title, note = "[!] EVICT", "PodDisruptionBudgets are respected."
+	case "restart":
+		title, note = "[!] RESTART", "Deployments, StatefulSets and DaemonSets get one rollout restart.\nOther controlled pods are deleted and recreated; bare pods are skipped."
+	case "label":
+		color, title, note = cCyan, "LABEL", "Labels: "+b.labelText
+	}
+	var s strings.Builder
+	s.WriteString(lipgloss.NewStyle().Foreground(color).Bold(true).Render(fmt.Sprintf("%s %d PODS", title, len(b.pods))) + "\n\n")
+	limit := max(m.height/2, 5)
+	for i, p := range b.pods {
+		if i == limit {
+			s.WriteString(footerStyle.Render(fmt.Sprintf("… %d more", len(b.pods)-limit)) + "\n")
+			break
+		}
+		s.WriteString(lipgloss.NewStyle().Foreground(cSecondary).Render(fmt.Sprintf("%-20s %-50s", truncate(p.Namespace, 20), truncate(p.Name, 50))) + " " + lipgloss.NewStyle().Foreground(cDim).Render(p.Status) + "\n")
+	}
+	s.WriteString("\n" + lipgloss.NewStyle().Foreground(cDim).Render(note) + "\n\n")
+	keys := lipgloss.NewStyle().Foreground(cGreen).Bold(true).Render("[y] Confirm") + " / " + lipgloss.NewStyle().Foreground(cDim).Render("[n] Cancel")
+	if b.action == "delete" {
+		keys += "\n" + lipgloss.NewStyle().Foreground(cDim).Render("[g] Grace period  [f] Force")
+	}
+	s.WriteString(keys)
+	box := modalStyle.BorderForeground(color).Align(lipgloss.Left).Render(s.String())
+	view := strings.Repeat("\n", m.height/8) + lipgloss.PlaceHorizontal(m.width, lipgloss.Center, box)
+	if m.promptAction != "" {
+		view += "\n\n" + lipgloss.PlaceHorizontal(m.width, lipgloss.Center, m.promptView())
+	}
+	return view
+}
+
+func (m model) bulkResultView() string {
+	res := m.bulkResults
+	var s strings.Builder
+	s.WriteString(headerStyle.Render(fmt.Sprintf(" %s RESULTS ", strings.ToUpper(res.action))) + "\n\n")
+	limit := max(m.height-12, 5)
+	for i, r := range res.results {
+		if i == limit {
+			s.WriteString(footerStyle.Render(fmt.Sprintf("… %d more", len(res.results)-limit)) + "\n")
+			break
+		}
+		mark, detail := lipgloss.NewStyle().Foreground(cGreen).Render("OK  "), r.note
+		if r.err != nil {
+			mark, detail = lipgloss.NewStyle().Foreground(cRed).Bold(true).Render("FAIL"), r.err.Error()
+		}
+		s.WriteString(mark + " " + lipgloss.NewStyle().Foreground(cSecondary).Render(fmt.Sprintf("%-50s", truncate(r.pod.Namespace+"/"+r.pod.Name, 50))) + " " + lipgloss.NewStyle().Foreground(cDim).Render(truncate(detail, 80)) + "\n")
+	}
+	s.WriteString(footerStyle.Render("\nFailed pods stay marked.  [Esc] Close"))
+	box := modalStyle.Align(lipgloss.Left).Render(s.String())
+	return strings.Repeat("\n", m.height/8) + lipgloss.PlaceHorizontal(m.width, lipgloss.Center, box) + "\n" + lipgloss.NewStyle().Foreground(cPrimary).Padding(0, 2).Render(m.msg)
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// --- CLEANSE ---
+// Cleanse deletes the pods of one namespace that match a filter: any
+// checked phase or reason, at least minAge old and matching the selector.
+// The preview is exactly the list that gets deleted.
+var (
+	cleansePhases  = []string{"Failed", "Succeeded", "Pending", "Unknown"}
+	cleanseReasons = []string{"Evicted", "Completed", "CrashLoopBackOff", "Error", "OOMKilled", "ImagePullBackOff", "ErrImagePull"}
+)
+
+type cleanseFilter struct {
+	checked  map[string]bool // Phases and reasons
+	minAge   time.Duration
+	selector labels.Selector // nil matches everything
+	cursor   int
+}
+
+func newCleanseFilter() *cleanseFilter {
+	return &cleanseFilter{checked: map[string]bool{"Failed": true, "Evicted": true, "Completed": true, "CrashLoopBackOff": true}}
+}
+
+func (f *cleanseFilter) items() []string {
+	return append(append([]string{}, cleansePhases...), cleanseReasons...)
+}
+
+// podReasons collects the pod-level reason (Evicted, NodeLost...) and the
+// container reasons that describe the pod as a whole. Finished init
+// containers and exited sidecars of a running pod also say "Completed" or
+// "Error", so init containers only count while the pod is Pending and
+// terminated reasons only once the pod itself has ended.
+func podReasons(p *corev1.Pod) []string {
+	var r []string
+	if p.Status.Reason != "" {
+		r = append(r, p.Status.Reason)
+	}
+	statuses := p.Status.ContainerStatuses
+	if p.Status.Phase == corev1.PodPending {
+		statuses = append(append([]corev1.ContainerStatus{}, p.Status.InitContainerStatuses...), statuses...)
+	}
+	ended := p.Status.Phase == corev1.PodFailed || p.Status.Phase == corev1.PodSucceeded
+	for _, c := range statuses {
+		if c.State.Waiting != nil && c.State.Waiting.Reason != "" {
+			r = append(r, c.State.Waiting.Reason)
+		}
+		if ended && c.State.Terminated != nil && c.State.Terminated.Reason != "" {
+			r = append(r, c.State.Terminated.Reason)
+		}
+	}
+	return r
+}
+
+// match reports whether the pod is selected and why.
+func (f *cleanseFilter) match(p *corev1.Pod) (string, bool) {
+	if f.minAge > 0 && time.Since(p.CreationTimestamp.Time) < f.minAge {
+		return "", false
+	}
+	if f.selector != nil && !f.selector.Matches(labels.Set(p.Labels)) {
+		return "", false
+	}
+	for _, r := range podReasons(p) {
+		if f.checked[r] {
+			return r, true
+		}
+	}
+	if f.checked[string(p.Status.Phase)] {
+		return string(p.Status.Phase), true
+	}
+	return "", false
+}
+
+func (m model) cleansePreview() ([]*corev1.Pod, []string) {
+	ns := m.namespaces[m.currentNsIdx]
+	var pods []*corev1.Pod
+	var why []string
+	for _, p := range m.watcher.listPods() {
+		if p.Namespace != ns {
+			continue
+		}
+		if r, ok := m.cleanse.match(p); ok {
+			pods = append(pods, p)
+			why = append(why, r)
+		}
+	}
+	return pods, why
+}
+
+// parseAge accepts Go durations plus a "d" suffix for days.
+func parseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "0" {
+		return 0, nil
+	}
+	if d, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(d)
+		return time.Duration(n) * 24 * time.Hour, err
+	}
+	return time.ParseDuration(s)
+}
+
+func (m *model) submitCleanseAge(value string) {
+	d, err := parseAge(value)
+	if err != nil || d < 0 {
+		m.msg = fmt.Sprintf("Invalid age %q (e.g. 30m, 6h, 2d)", value)
+		return
+	}
+	m.cleanse.minAge = d
+}
+
+func (m *model) submitCleanseSelector(value string) {
+	if strings.TrimSpace(value) == "" {
+		m.cleanse.selector = nil
+		return
+	}
+	sel, err := labels.Parse(value)
+	if err != nil {
+		m.msg = fmt.Sprintf("Invalid selector %q", value)
+		return
+	}
+	m.cleanse.selector = sel
+}
+
+// cleanseNamespace deletes the previewed pods one by one. The UID
+// precondition spares a pod recreated under the same name meanwhile.
+func cleanseNamespace(client *kubernetes.Clientset, namespace string, pods []*corev1.Pod) tea.Cmd {
+	return func() tea.Msg {
+		deleted, failed := 0, 0
+		var lastErr error
+		for _, p := range pods {
+			uid := p.UID
+			err := client.CoreV1().Pods(namespace).Delete(context.TODO(), p.Name, metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid}})
+			if err != nil {
+				failed++
+				lastErr = err
+				continue
+			}
+			deleted++
+		}
+		if failed > 0 {
+			return apiErrMsg{action: fmt.Sprintf("Cleanse of '%s' (%d of %d deleted)", namespace, deleted, len(pods)), err: lastErr}
+		}
+		return deleteMsg(fmt.Sprintf("Cleansed %d pods in '%s'.", deleted, namespace))
+	}
+}
+
+func (m model) cleanseConfirmView() string {
+	f := m.cleanse
+	var left strings.Builder
+	left.WriteString(colHeadStyle.Render("PHASE") + "\n")
+	for i, item := range f.items() {
+		if i == len(cleansePhases) {
+			left.WriteString("\n" + colHeadStyle.Render("REASON") + "\n")
+		}
+		check := "[ ] "
+		if f.checked[item] {
+			check = "[x] "
+		}
+		style := lipgloss.NewStyle().Foreground(cSecondary)
+		cursor := "  "
+		if i == f.cursor {
+			cursor = "> "
+			style = lipgloss.NewStyle().Foreground(cCyan).Bold(true)
+		}
+		left.WriteString(style.Render(cursor+check+item) + "\n")
+	}
+	age, sel := "any", "none"
+	if f.minAge > 0 {
+		age = shortAge(f.minAge)
+	}
+	if f.selector != nil {
+		sel = f.selector.String()
+	}
+	left.WriteString("\n" + lipgloss.NewStyle().Foreground(cSecondary).Render("Older than: "+age+"\nSelector:   "+truncate(sel, 30)))
+
+	pods, why := m.cleansePreview()
+	var right strings.Builder
+	right.WriteString(colHeadStyle.Render(fmt.Sprintf("WILL DELETE (%d)", len(pods))) + "\n")
+	limit := max(m.height/2, 5)
+	for i, p := range pods {
+		if i == limit {
+			right.WriteString(footerStyle.Render(fmt.Sprintf("… %d more", len(pods)-limit)) + "\n")
+			break
+		}
+		right.WriteString(lipgloss.NewStyle().Foreground(cRed).Render(fmt.Sprintf("%-45s", truncate(p.Name, 45))) + " " +
+			lipgloss.NewStyle().Foreground(cDim).Render(fmt.Sprintf("%-18s %s", why[i], shortAge(time.Since(p.CreationTimestamp.Time)))) + "\n")
+	}
+	if len(pods) == 0 {
+		right.WriteString(lipgloss.NewStyle().Foreground(cDim).Render("No pods match.") + "\n")
+	}
+
+	body := lipgloss.JoinHorizontal(lipgloss.Top, lipgloss.NewStyle().PaddingRight(4).Render(left.String()), right.String())
+	title := lipgloss.NewStyle().Foreground(cRed).Bold(true).Render("CLEANSE NAMESPACE: " + m.namespaces[m.currentNsIdx])
+	keys := lipgloss.NewStyle().Foreground(cGreen).Bold(true).Render("[y] Delete listed pods") + " / " + lipgloss.NewStyle().Foreground(cDim).Render("[n] Cancel  [Space] Toggle  [a] Age  [l] Selector")
+	box := modalStyle.Align(lipgloss.Left).Render(title + "\n\n" + body + "\n\n" + keys)
+	view := strings.Repeat("\n", m.height/8) + lipgloss.PlaceHorizontal(m.width, lipgloss.Center, box)
+	if m.promptAction != "" {
+		view += "\n\n" + lipgloss.PlaceHorizontal(m.width, lipgloss.Center, m.promptView())
+	}
+	return view
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestCleanseFilterMatch(t *testing.T) {
+	terminated := func(name, reason string) corev1.ContainerStatus {
+		return corev1.ContainerStatus{Name: name, State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: reason}}}
+	}
+	waiting := func(name, reason string) corev1.ContainerStatus {
+		return corev1.ContainerStatus{Name: name, State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason}}}
+	}
+	running := corev1.ContainerStatus{Name: "app", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}}
+	pod := func(phase corev1.PodPhase, age time.Duration, mutate func(*corev1.Pod)) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "p", Labels: map[string]string{"app": "web"}, CreationTimestamp: metav1.NewTime(time.Now().Add(-age))},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+		if mutate != nil {
+			mutate(p)
+		}
+		return p
+	}
+
+	tests := []struct {
+		name   string
+		pod    *corev1.Pod
+		minAge time.Duration
+		sel    string
+		want   bool
+	}{
+		{"running with completed init container", pod(corev1.PodRunning, time.Hour, func(p *corev1.Pod) {
+			p.Status.InitContainerStatuses = []corev1.ContainerStatus{terminated("init", "Completed")}
+			p.Status.ContainerStatuses = []corev1.ContainerStatus{running}
+		}), 0, "", false},
+		{"running with exited sidecar", pod(corev1.PodRunning, time.Hour, func(p *corev1.Pod) {
+			p.Status.ContainerStatuses = []corev1.ContainerStatus{running, terminated("sidecar", "Completed")}
+		}), 0, "", false},
+		{"running and crash looping", pod(corev1.PodRunning, time.Hour, func(p *corev1.Pod) {
+			p.Status.ContainerStatuses = []corev1.ContainerStatus{waiting("app", "CrashLoopBackOff")}
+		}), 0, "", true},
+		{"pending with crash looping init container", pod(corev1.PodPending, time.Hour, func(p *corev1.Pod) {
+			p.Status.InitContainerStatuses = []corev1.ContainerStatus{waiting("init", "CrashLoopBackOff")}
+		}), 0, "", true},
+		{"succeeded job pod", pod(corev1.PodSucceeded, time.Hour, func(p *corev1.Pod) {
+			p.Status.ContainerStatuses = []corev1.ContainerStatus{terminated("app", "Completed")}
+		}), 0, "", true},
+		{"evicted", pod(corev1.PodFailed, time.Hour, func(p *corev1.Pod) { p.Status.Reason = "Evicted" }), 0, "", true},
+		{"healthy running pod", pod(corev1.PodRunning, time.Hour, func(p *corev1.Pod) {
+			p.Status.ContainerStatuses = []corev1.ContainerStatus{running}
+		}), 0, "", false},
+		{"failed but younger than min age", pod(corev1.PodFailed, time.Minute, nil), time.Hour, "", false},
+		{"failed but selector does not match", pod(corev1.PodFailed, time.Hour, nil), 0, "app=api", false},
+		{"failed and selector matches", pod(corev1.PodFailed, time.Hour, nil), 0, "app=web", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newCleanseFilter()
+			f.minAge = tt.minAge
+			if tt.sel != "" {
+				sel, err := labels.Parse(tt.sel)
+				if err != nil {
+					t.Fatal(err)
+				}
+				f.selector = sel
+			}
+			if _, got := f.match(tt.pod); got != tt.want {
+				t.Errorf("match() = %v, want %v (reasons %v)", got, tt.want, podReasons(tt.pod))
+			}
+		})
+	}
+}
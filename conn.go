@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// --- CONNECTION STATE ---
+// The API server is probed on an interval, backing off while it is
+// unreachable. Watch errors reported by the informers and failed actions
+// mark the connection degraded until a quiet probe interval has passed.
+const (
+	probeInterval = 15 * time.Second
+	probeMaxDelay = 30 * time.Second
+)
+
+type connStatus int
+
+const (
+	connUnknown connStatus = iota // No probe has finished yet
+	connOK
+	connDegraded
+	connDown
+)
+
+type connState struct {
+	status      connStatus
+	lastSuccess time.Time
+	lastErr     error
+	lastErrAt   time.Time
+	failures    int // Consecutive failed probes; drives the backoff
+	nextProbe   time.Time
+}
+
+// apiErrMsg carries a failed API call. action names what the user asked
+// for; it is empty for background watch errors.
+type apiErrMsg struct {
+	action string
+	err    error
+}
+
+type probeMsg struct{ err error }
+
+// probeAPI waits delay, then asks the server for its version. Any API
+// status, even Forbidden, proves the server is reachable.
+func probeAPI(c *kubernetes.Clientset, delay time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		time.Sleep(delay)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		err := c.Discovery().RESTClient().Get().AbsPath("/version").Do(ctx).Error()
+		var status apierrors.APIStatus
+		if errors.As(err, &status) {
+			err = nil
+		}
+		return probeMsg{err: err}
+	}
+}
+
+// probed records a probe result and schedules the next one.
+func (s *connState) probed(c *kubernetes.Clientset, err error) tea.Cmd {
+	now := time.Now()
+	delay := probeInterval
+	if err != nil {
+		s.failures++
+		s.lastErr, s.lastErrAt = err, now
+		s.status = connDown
+		delay = min(time.Second<<min(s.failures-1, 5), probeMaxDelay)
+	} else {
+		s.failures = 0
+		s.lastSuccess = now
+		s.status = connOK
+		if now.Sub(s.lastErrAt) < probeInterval {
+			s.status = connDegraded
+		}
+	}
+	s.nextProbe = now.Add(delay)
+	return probeAPI(c, delay)
+}
+
+// failed notes an error from a watch or an action. Errors the server
+// answered with a status (NotFound, Conflict...) say nothing about the
+// connection unless they are server-side failures.
+func (s *connState) failed(err error, watch bool) {
+	var status apierrors.APIStatus
+	if !watch && errors.As(err, &status) && !apierrors.IsServerTimeout(err) && !apierrors.IsTimeout(err) &&
+		!apierrors.IsServiceUnavailable(err) && !apierrors.IsInternalError(err) && !apierrors.IsTooManyRequests(err) {
+		return
+	}
+	s.lastErr, s.lastErrAt = err, time.Now()
+	if s.status != connDown {
+		s.status = connDegraded
+	}
+}
+
+func (s connState) label() string {
+	since := "never"
+	if !s.lastSuccess.IsZero() {
+		since = shortAge(time.Since(s.lastSuccess)) + " ago"
+	}
+	switch s.status {
+	case connOK:
+		return lipgloss.NewStyle().Foreground(cGreen).Render("● CONNECTED")
+	case connDegraded:
+		return lipgloss.NewStyle().Foreground(cOrange).Render(fmt.Sprintf("◐ DEGRADED (%s, last ok %s)", truncate(s.lastErr.Error(), 40), since))
+	case connDown:
+		retry := max(time.Until(s.nextProbe).Round(time.Second), 0)
+		return lipgloss.NewStyle().Foreground(cRed).Bold(true).Render(fmt.Sprintf("✖ UNREACHABLE (last ok %s, retry in %s)", since, retry))
+	}
+	return lipgloss.NewStyle().Foreground(cDim).Render("○ CONNECTING")
+}
+
+// --- WATCH ERRORS ---
+// watchError replaces client-go's default handler, which logs to stderr
+// underneath the TUI. The reflector keeps retrying with its own backoff.
+func (w *clusterWatcher) watchError(_ *cache.Reflector, err error) {
+	// The same cases client-go's DefaultWatchErrorHandler treats as routine:
+	// watch expiry, a closed connection, a relist after "too old", shutdown
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, context.Canceled) ||
+		apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+		return
+	}
+	select {
+	case w.errs <- err:
+	default: // One pending error is enough to show
+	}
+}
+
+func (w *clusterWatcher) waitForErrors() tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case err := <-w.errs:
+			return apiErrMsg{err: err}
+		case <-w.stop:
+			return nil
+		}
+	}
+}
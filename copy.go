@@ -0,0 +1,309 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// --- FILE COPY ---
+// Like `kubectl cp`, files travel as a tar stream over exec, so the
+// container needs a tar binary. The remote path names the file or directory
+// itself on both ends; an existing local directory receives downloads
+// inside it.
+type copyJob struct {
+	toPod                     bool
+	namespace, pod, container string
+	remote, local             string
+	bytes                     atomic.Int64 // File content moved so far
+	total                     atomic.Int64 // Known for uploads only
+	started                   time.Time
+}
+
+type copyDoneMsg struct {
+	job   *copyJob
+	files int
+	err   error
+}
+
+type copyTickMsg time.Time
+
+func copyTick() tea.Cmd {
+	return tea.Tick(250*time.Millisecond, func(t time.Time) tea.Msg { return copyTickMsg(t) })
+}
+
+// promptCopy opens the first of the two path prompts: the source side.
+func (m *model) promptCopy(pod PodInfo, container string, toPod bool) {
+	m.selectedPod = &pod
+	m.copyDraft = &copyJob{toPod: toPod, namespace: pod.Namespace, pod: pod.Name, container: container}
+	if toPod {
+		m.openPrompt("cp-local", "local file or dir", "")
+	} else {
+		m.openPrompt("cp-remote", "path in "+container, "")
+	}
+}
+
+// submitCopyPath records one path and either asks for the other or starts
+// the transfer.
+func (m *model) submitCopyPath(action, value string) tea.Cmd {
+	j := m.copyDraft
+	value = strings.TrimSpace(value)
+	if j == nil || value == "" {
+		m.copyDraft = nil
+		return nil
+	}
+	if action == "cp-local" {
+		j.local = value
+	} else {
+		j.remote = value
+	}
+	switch {
+	case j.toPod && j.remote == "":
+		m.openPrompt("cp-remote", "destination in "+j.container, path.Join("/tmp", filepath.Base(j.local)))
+		return textinput.Blink
+	case !j.toPod && j.local == "":
+		m.openPrompt("cp-local", "save to", path.Base(j.remote))
+		return textinput.Blink
+	}
+	m.copyDraft = nil
+	if m.copy != nil {
+		m.msg = "A copy is already running."
+		return nil
+	}
+	j.started = time.Now()
+	m.copy = j
+	m.msg = j.progress()
+	return tea.Batch(j.run(m.restConfig, m.client), copyTick())
+}
+
+func (j *copyJob) run(cfg *rest.Config, c *kubernetes.Clientset) tea.Cmd {
+	return func() tea.Msg {
+		var files int
+		var err error
+		if j.toPod {
+			files, err = j.upload(cfg, c)
+		} else {
+			files, err = j.download(cfg, c)
+		}
+		return copyDoneMsg{job: j, files: files, err: err}
+	}
+}
+
+func splitRemote(p string) (string, string) {
+	dir, base := path.Split(path.Clean(p))
+	if dir == "" {
+		dir = "."
+	}
+	return dir, base
+}
+
+// download runs `tar cf -` in the container and unpacks the stream locally.
+func (j *copyJob) download(cfg *rest.Config, c *kubernetes.Clientset) (int, error) {
+	dir, base := splitRemote(j.remote)
+	ex, err := newExecutor(cfg, c, j.namespace, j.pod, "exec", runtimeOptions{container: j.container, command: []string{"tar", "cf", "-", "-C", dir, base}})
+	if err != nil {
+		return 0, err
+	}
+	pr, pw := io.Pipe()
+	var stderr bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pw.CloseWithError(ex.StreamWithContext(context.TODO(), remotecommand.StreamOptions{Stdout: pw, Stderr: &stderr}))
+	}()
+	files, err := untar(pr, base, j.local, &j.bytes)
+	pr.CloseWithError(err) // Unblocks the stream if we stopped reading early
+	<-done
+	if err != nil && stderr.Len() > 0 {
+		return files, errors.New(strings.TrimSpace(stderr.String()))
+	}
+	return files, err
+}
+
+// untar writes the entries below local. Anything but regular files and
+// directories is skipped, and entries resolving outside local are refused.
+func untar(r io.Reader, base, local string, n *atomic.Int64) (int, error) {
+	info, err := os.Stat(local)
+	intoDir := err == nil && info.IsDir()
+	root := filepath.Clean(local)
+	tr := tar.NewReader(r)
+	files := 0
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return files, err
+		}
+		name := path.Clean(h.Name)
+		dest := filepath.Join(root, filepath.FromSlash(name))
+		if !intoDir {
+			rest := strings.TrimPrefix(name, base)
+			if rest != "" && !strings.HasPrefix(rest, "/") {
+				continue
+			}
+			dest = filepath.Join(root, filepath.FromSlash(rest))
+		}
+		if rel, err := filepath.Rel(root, dest); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return files, fmt.Errorf("refusing to write %s outside %s", h.Name, local)
+		}
+		switch h.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(dest, 0o755); err != nil {
+				return files, err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+				return files, err
+			}
+			f, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, h.FileInfo().Mode().Perm())
+			if err != nil {
+				return files, err
+			}
+			_, err = io.Copy(f, countingReader{tr, n})
+			f.Close()
+			if err != nil {
+				return files, err
+			}
+			files++
+		}
+	}
+}
+
+// upload streams a tar of the local path into `tar xf -` in the container.
+func (j *copyJob) upload(cfg *rest.Config, c *kubernetes.Clientset) (int, error) {
+	if _, err := os.Stat(j.local); err != nil {
+		return 0, err
+	}
+	var total int64
+	filepath.Walk(j.local, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() {
+			total += info.Size()
+		}
+		return nil
+	})
+	j.total.Store(total)
+	dir, base := splitRemote(j.remote)
+	ex, err := newExecutor(cfg, c, j.namespace, j.pod, "exec", runtimeOptions{container: j.container, command: []string{"tar", "xmf", "-", "-C", dir}, stdin: true})
+	if err != nil {
+		return 0, err
+	}
+	pr, pw := io.Pipe()
+	var files int
+	tarErr := make(chan error, 1)
+	go func() {
+		var err error
+		files, err = writeTar(pw, j.local, base, &j.bytes)
+		pw.CloseWithError(err)
+		tarErr <- err
+	}()
+	var stderr bytes.Buffer
+	err = ex.StreamWithContext(context.TODO(), remotecommand.StreamOptions{Stdin: pr, Stdout: io.Discard, Stderr: &stderr})
+	pr.CloseWithError(err)
+	werr := <-tarErr
+	// When the remote side failed, writeTar only saw the closed pipe; tar's
+	// stderr ("tar: not found") is the real reason
+	if err != nil && stderr.Len() > 0 {
+		return files, errors.New(strings.TrimSpace(stderr.String()))
+	}
+	// A local read error explains a truncated archive better than tar does
+	if werr != nil {
+		return files, werr
+	}
+	return files, err
+}
+
+func writeTar(w io.Writer, src, base string, n *atomic.Int64) (int, error) {
+	tw := tar.NewWriter(w)
+	files := 0
+	err := filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			return nil // Symlinks, sockets, devices
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		h, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		h.Name = path.Join(base, filepath.ToSlash(rel))
+		if info.IsDir() {
+			h.Name += "/"
+		}
+		if err := tw.WriteHeader(h); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if _, err := io.Copy(tw, countingReader{f, n}); err != nil {
+			return err
+		}
+		files++
+		return nil
+	})
+	if err != nil {
+		return files, err
+	}
+	return files, tw.Close()
+}
+
+type countingReader struct {
+	r io.Reader
+	n *atomic.Int64
+}
+
+func (c countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+func (j *copyJob) describe() string {
+	remote := fmt.Sprintf("%s:%s", j.pod, j.remote)
+	if j.toPod {
+		return j.local + " → " + remote
+	}
+	return remote + " → " + j.local
+}
+
+// progress is the status line while the copy runs.
+func (j *copyJob) progress() string {
+	done := j.bytes.Load()
+	elapsed := time.Since(j.started).Seconds()
+	rate := ""
+	if elapsed >= 1 {
+		rate = fmt.Sprintf(" %s/s", humanBytes(int64(float64(done)/elapsed)))
+	}
+	if total := j.total.Load(); total > 0 {
+		return fmt.Sprintf("Copying %s  %d%% (%s/%s)%s", j.describe(), done*100/total, humanBytes(done), humanBytes(total), rate)
+	}
+	return fmt.Sprintf("Copying %s  %s%s", j.describe(), humanBytes(done), rate)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// --- DEBUG CONTAINER ---
+// Distroless images have no shell to exec into, so an ephemeral container
+// is added to the pod instead, sharing the process namespace of the target
+// container, and attached to like `kubectl debug -it --target`.
+const debugStartTimeout = 2 * time.Minute
+
+// promptDebug asks for the image, pre-filled with the -debug-image default.
+func (m *model) promptDebug(pod PodInfo, container string) {
+	m.selectedPod = &pod
+	m.debugTarget = container
+	m.openPrompt("debug", "debug image", m.debugImage)
+}
+
+func startDebug(cfg *rest.Config, c *kubernetes.Clientset, namespace, pod, target, image string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.TODO()
+		p, err := c.CoreV1().Pods(namespace).Get(ctx, pod, metav1.GetOptions{})
+		if err != nil {
+			return execDoneMsg{what: "Debug", err: err}
+		}
+		name := "debugger-" + utilrand.String(5)
+		p.Spec.EphemeralContainers = append(p.Spec.EphemeralContainers, corev1.EphemeralContainer{
+			TargetContainerName: target,
+			EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+				Name:                     name,
+				Image:                    image,
+				ImagePullPolicy:          corev1.PullIfNotPresent,
+				Stdin:                    true,
+				TTY:                      true,
+				TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+			},
+		})
+		if _, err := c.CoreV1().Pods(namespace).UpdateEphemeralContainers(ctx, pod, p, metav1.UpdateOptions{FieldManager: "kube-pulse"}); err != nil {
+			return execDoneMsg{what: "Debug", err: err}
+		}
+		if err := waitForEphemeral(ctx, c, namespace, pod, name); err != nil {
+			return execDoneMsg{what: "Debug", err: err}
+		}
+		ex, err := newExecutor(cfg, c, namespace, pod, "attach", runtimeOptions{container: name, stdin: true, tty: true})
+		if err != nil {
+			return execDoneMsg{what: "Debug", err: err}
+		}
+		// The shell printed its prompt before we attached
+		hint := fmt.Sprintf("Attached to %s (%s) targeting %s. If you don't see a command prompt, try pressing enter.\r\n", name, image, target)
+		return shellReadyMsg{what: "Debug", pod: pod, session: &ttySession{executor: ex, hint: hint}}
+	}
+}
+
+// waitForEphemeral polls until the container runs, failing early on image
+// pull errors and on containers that exit before we can attach.
+func waitForEphemeral(ctx context.Context, c *kubernetes.Clientset, namespace, pod, name string) error {
+	return wait.PollUntilContextTimeout(ctx, time.Second, debugStartTimeout, true, func(ctx context.Context) (bool, error) {
+		p, err := c.CoreV1().Pods(namespace).Get(ctx, pod, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		for _, s := range p.Status.EphemeralContainerStatuses {
+			if s.Name != name {
+				continue
+			}
+			switch {
+			case s.State.Running != nil:
+				return true, nil
+			case s.State.Terminated != nil:
+				return false, fmt.Errorf("%s exited: %s", name, s.State.Terminated.Reason)
+			case s.State.Waiting != nil:
+				switch s.State.Waiting.Reason {
+				case "ErrImagePull", "ImagePullBackOff", "InvalidImageName", "CreateContainerError":
+					return false, fmt.Errorf("%s: %s", s.State.Waiting.Reason, s.State.Waiting.Message)
+				}
+			}
+		}
+		return false, nil
+	})
+}
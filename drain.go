@@ -0,0 +1,325 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// --- CORDON & DRAIN ---
+// Drain follows `kubectl drain`: cordon, then evict every pod through the
+// Eviction API so PodDisruptionBudgets are honoured. Evictions refused by
+// a budget are retried until they pass or the drain is aborted. DaemonSet
+// and mirror pods are skipped; their controllers would put them right back.
+const (
+	evictRetryInterval = 5 * time.Second
+	evictWaitTimeout   = 2 * time.Minute // For an evicted pod to disappear
+)
+
+type nodeMsg string
+
+func cordonNode(c *kubernetes.Clientset, name string, unschedulable bool) tea.Cmd {
+	return func() tea.Msg {
+		verb := "Cordoned"
+		if !unschedulable {
+			verb = "Uncordoned"
+		}
+		if err := setUnschedulable(context.TODO(), c, name, unschedulable); err != nil {
+			return nodeMsg(fmt.Sprintf("%s failed: %v", strings.TrimSuffix(verb, "ed"), err))
+		}
+		return nodeMsg(fmt.Sprintf("%s %s.", verb, name))
+	}
+}
+
+func setUnschedulable(ctx context.Context, c *kubernetes.Clientset, name string, unschedulable bool) error {
+	patch := []byte(fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable))
+	_, err := c.CoreV1().Nodes().Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{FieldManager: "kube-pulse"})
+	return err
+}
+
+type drainState int
+
+const (
+	drainPending drainState = iota
+	drainEvicting
+	drainBlocked // Refused by a PodDisruptionBudget; retrying
+	drainTerminating
+	drainEvicted
+	drainFailed
+	drainSkipped
+)
+
+type drainPod struct {
+	Namespace, Name string
+	state           drainState
+	note            string
+}
+
+type drainJob struct {
+	id        int
+	node      string
+	pods      []drainPod
+	unmanaged int // Pods without a controller; they will not come back
+	cancel    context.CancelFunc
+	events    chan drainEventMsg
+	done      bool
+}
+
+type drainEventMsg struct {
+	id    int
+	index int // -1 for job-level events
+	state drainState
+	note  string
+	done  bool
+}
+
+func waitForDrain(events <-chan drainEventMsg) tea.Cmd {
+	return func() tea.Msg { return <-events }
+}
+
+// planDrain lists the node's pods from the informer cache.
+func (m *model) planDrain(node string) *drainJob {
+	m.drainSeq++
+	j := &drainJob{id: m.drainSeq, node: node}
+	for _, p := range m.watcher.listPods() {
+		if p.Spec.NodeName != node {
+			continue
+		}
+		dp := drainPod{Namespace: p.Namespace, Name: p.Name}
+		ref := metav1.GetControllerOf(p)
+		switch {
+		case p.Annotations[corev1.MirrorPodAnnotationKey] != "":
+			dp.state, dp.note = drainSkipped, "mirror pod"
+		case ref != nil && ref.Kind == "DaemonSet":
+			dp.state, dp.note = drainSkipped, "DaemonSet"
+		case ref == nil:
+			j.unmanaged++
+		}
+		j.pods = append(j.pods, dp)
+	}
+	return j
+}
+
+func (j *drainJob) count(state drainState) int {
+	n := 0
+	for _, p := range j.pods {
+		if p.state == state {
+			n++
+		}
+	}
+	return n
+}
+
+func (m *model) startDrain() tea.Cmd {
+	j := m.drain
+	ctx, cancel := context.WithCancel(context.Background())
+	j.cancel = cancel
+	j.events = make(chan drainEventMsg, 64)
+	pods := append([]drainPod(nil), j.pods...)
+	go runDrain(ctx, m.client, j.id, j.node, pods, j.events)
+	return waitForDrain(j.events)
+}
+
+func runDrain(ctx context.Context, c *kubernetes.Clientset, id int, node string, pods []drainPod, events chan<- drainEventMsg) {
+	if err := setUnschedulable(ctx, c, node, true); err != nil {
+		events <- drainEventMsg{id: id, index: -1, note: fmt.Sprintf("Cordon failed: %v", err), done: true}
+		return
+	}
+	events <- drainEventMsg{id: id, index: -1, note: fmt.Sprintf("Cordoned %s, evicting...", node)}
+	var wg sync.WaitGroup
+	for i, p := range pods {
+		if p.state == drainSkipped {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			send := func(s drainState, note string) { events <- drainEventMsg{id: id, index: i, state: s, note: note} }
+			evictWithRetry(ctx, c, p.Namespace, p.Name, send)
+		}()
+	}
+	wg.Wait()
+	events <- drainEventMsg{id: id, index: -1, done: true}
+}
+
+// evictWithRetry evicts one pod and waits for it to go away.
+func evictWithRetry(ctx context.Context, c *kubernetes.Clientset, namespace, name string, send func(drainState, string)) {
+	pod, err := c.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		send(drainEvicted, "already gone")
+		return
+	} else if err != nil {
+		send(drainFailed, err.Error())
+		return
+	}
+	pdb, looked := "", false // Looked up on the first refusal
+	for attempt := 1; ; attempt++ {
+		send(drainEvicting, "")
+		err := evictPod(ctx, c, namespace, name, nil)
+		if err == nil {
+			break
+		}
+		switch {
+		case apierrors.IsNotFound(err):
+			send(drainEvicted, "already gone")
+			return
+		case apierrors.IsTooManyRequests(err):
+			if !looked {
+				pdb, looked = blockingPDBs(c, pod), true
+			}
+			// No budget selects the pod: plain API rate limiting
+			note := fmt.Sprintf("eviction refused (429), retry %d", attempt)
+			if pdb != "" {
+				note = fmt.Sprintf("PDB %s, retry %d", pdb, attempt)
+			}
+			send(drainBlocked, note)
+			select {
+			case <-time.After(evictRetryInterval):
+				continue
+			case <-ctx.Done():
+				send(drainFailed, "aborted")
+				return
+			}
+		default:
+			send(drainFailed, apiErrorText(err))
+			return
+		}
+	}
+	send(drainTerminating, "")
+	deadline := time.After(evictWaitTimeout)
+	for {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-deadline:
+			send(drainEvicted, "still terminating")
+			return
+		case <-ctx.Done():
+			send(drainEvicted, "not waiting for termination")
+			return
+		}
+		p, err := c.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) || (err == nil && p.UID != pod.UID) {
+			send(drainEvicted, "")
+			return
+		}
+	}
+}
+
+// evictPod posts a policy/v1 Eviction. opts may carry a grace period.
+func evictPod(ctx context.Context, c *kubernetes.Clientset, namespace, name string, opts *metav1.DeleteOptions) error {
+	return c.PolicyV1().Evictions(namespace).Evict(ctx, &policyv1.Eviction{
+		ObjectMeta:    metav1.ObjectMeta{Name: name, Namespace: namespace},
+		DeleteOptions: opts,
+	})
+}
+
+func (m *model) applyDrainEvent(msg drainEventMsg) tea.Cmd {
+	j := m.drain
+	if j == nil || msg.id != j.id {
+		return nil
+	}
+	if msg.index >= 0 {
+		j.pods[msg.index].state, j.pods[msg.index].note = msg.state, msg.note
+	} else if msg.note != "" {
+		m.msg = msg.note
+	}
+	if msg.done {
+		j.done = true
+		if msg.note == "" {
+			m.msg = j.summary()
+		}
+		return nil
+	}
+	return waitForDrain(j.events)
+}
+
+func (j *drainJob) summary() string {
+	s := fmt.Sprintf("Drain of %s: %d evicted, %d failed, %d skipped", j.node, j.count(drainEvicted), j.count(drainFailed), j.count(drainSkipped))
+	if !j.done {
+		s += fmt.Sprintf(", %d in progress", len(j.pods)-j.count(drainEvicted)-j.count(drainFailed)-j.count(drainSkipped))
+	}
+	return s
+}
+
+func (m model) updateDrain(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "x":
+		if m.drain != nil && !m.drain.done {
+			m.drain.cancel()
+			m.msg = "Aborting drain..."
+		}
+	case "esc", "q":
+		m.state = viewNodes
+		if m.drain != nil && m.drain.done {
+			m.drain = nil
+		}
+	}
+	return m, nil
+}
+
+func (m model) drainConfirmView() string {
+	j := m.drain
+	evict := len(j.pods) - j.count(drainSkipped)
+	lines := []string{
+		lipgloss.NewStyle().Foreground(cRed).Bold(true).Render("[!] DRAIN NODE"), "",
+		lipgloss.NewStyle().Foreground(cSecondary).Render("This will cordon and evict pods from:"),
+		lipgloss.NewStyle().Foreground(cRed).Bold(true).Render(j.node), "",
+		fmt.Sprintf("%d pods to evict, %d skipped (DaemonSet/mirror)", evict, j.count(drainSkipped)),
+	}
+	if j.unmanaged > 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(cOrange).Render(fmt.Sprintf("%d pods have no controller and will not come back", j.unmanaged)))
+	}
+	lines = append(lines, "", lipgloss.NewStyle().Foreground(cGreen).Bold(true).Render("[y] DRAIN")+" / "+lipgloss.NewStyle().Foreground(cDim).Render("[n] Cancel"))
+	box := modalStyle.Render(strings.Join(lines, "\n"))
+	return strings.Repeat("\n", m.height/3) + lipgloss.PlaceHorizontal(m.width, lipgloss.Center, box)
+}
+
+func (m model) drainView() string {
+	j := m.drain
+	var rows strings.Builder
+	limit := max(m.height-8, 5)
+	for i, p := range j.pods {
+		if i == limit {
+			rows.WriteString(footerStyle.Render(fmt.Sprintf("  … %d more", len(j.pods)-limit)) + "\n")
+			break
+		}
+		icon, style := "○", lipgloss.NewStyle().Foreground(cDim)
+		label := "pending"
+		switch p.state {
+		case drainEvicting:
+			icon, style, label = "○", lipgloss.NewStyle().Foreground(cOrange), "evicting"
+		case drainBlocked:
+			icon, style, label = "⏸", lipgloss.NewStyle().Foreground(cOrange), "blocked"
+		case drainTerminating:
+			icon, style, label = "○", lipgloss.NewStyle().Foreground(cCyan), "terminating"
+		case drainEvicted:
+			icon, style, label = "●", lipgloss.NewStyle().Foreground(cGreen), "evicted"
+		case drainFailed:
+			icon, style, label = "✖", lipgloss.NewStyle().Foreground(cRed), "failed"
+		case drainSkipped:
+			label = "skipped"
+		}
+		line := fmt.Sprintf("  %s %-12s %s/%s", icon, label, p.Namespace, p.Name)
+		if p.note != "" {
+			line += "  (" + p.note + ")"
+		}
+		rows.WriteString(style.Render(line) + "\n")
+	}
+	keys := "  [x] Abort  [Esc] Back (drain continues)"
+	if j.done {
+		keys = "  [Esc] Back"
+	}
+	return "\n" + headerStyle.Render(" DRAIN: "+j.node+" ") + "  " + footerStyle.Render(j.summary()) + "\n\n" + rows.String() + "\n" + footerStyle.Render(keys) + "\n" + lipgloss.NewStyle().Foreground(cPrimary).Padding(0, 2).Render(m.msg)
+}
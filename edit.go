@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/pmezard/go-difflib/difflib"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+// --- EDIT & APPLY ---
+// The object is written to a temp file and opened in $EDITOR with the TUI
+// suspended, the same way shells hand over the terminal. The result is
+// diffed against a fresh server copy and applied with Update only after
+// confirmation.
+type editSession struct {
+	namespace, name string
+	path            string // Temp file handed to the editor
+	original        string // What the editor was opened with
+	text            string // Last saved edit, reopened by "edit again"
+	edited          map[string]interface{}
+	diff            string
+	err             error // Last parse, validation or conflict error
+	conflict        bool
+}
+
+type editDoneMsg struct{ err error }
+type editDiffMsg struct {
+	diff string
+	err  error
+}
+type editApplyMsg struct{ err error }
+
+// editableYaml serializes obj the way it is offered for editing: without
+// managedFields and status, which the server would ignore anyway.
+func editableYaml(obj map[string]interface{}) (string, error) {
+	clean := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		if k != "status" {
+			clean[k] = v
+		}
+	}
+	if meta, ok := obj["metadata"].(map[string]interface{}); ok {
+		trimmed := make(map[string]interface{}, len(meta))
+		for k, v := range meta {
+			if k != "managedFields" {
+				trimmed[k] = v
+			}
+		}
+		clean["metadata"] = trimmed
+	}
+	out, err := yaml.Marshal(clean)
+	return string(out), err
+}
+
+// openEditor suspends the TUI and opens content in $EDITOR. Any previous
+// error is prepended as comments, like kubectl edit does.
+func openEditor(s *editSession, content string) tea.Cmd {
+	if s.err != nil {
+		var b strings.Builder
+		b.WriteString("# Please edit the object below. The last attempt failed:\n#\n")
+		for _, l := range strings.Split(apiErrorText(s.err), "\n") {
+			b.WriteString("# " + l + "\n")
+		}
+		b.WriteString("#\n")
+		content = b.String() + content
+	}
+	if err := os.WriteFile(s.path, []byte(content), 0o600); err != nil {
+		return func() tea.Msg { return editDoneMsg{err: err} }
+	}
+	editor := os.Getenv("KUBE_EDITOR")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	args := append(strings.Fields(editor), s.path)
+	return tea.ExecProcess(exec.Command(args[0], args[1:]...), func(err error) tea.Msg { return editDoneMsg{err: err} })
+}
+
+// startEdit creates the temp file and opens the editor on the cached object.
+func (m *model) startEdit() tea.Cmd {
+	content, err := editableYaml(m.yamlObj)
+	if err != nil {
+		m.msg = fmt.Sprintf("Edit failed: %v", err)
+		return nil
+	}
+	f, err := os.CreateTemp("", "kube-pulse-*.yaml")
+	if err != nil {
+		m.msg = fmt.Sprintf("Edit failed: %v", err)
+		return nil
+	}
+	f.Close()
+	m.edit = &editSession{namespace: m.selectedPod.Namespace, name: m.selectedPod.Name, path: f.Name(), original: content}
+	return openEditor(m.edit, content)
+}
+
+func (m *model) endEdit() {
+	if m.edit != nil {
+		os.Remove(m.edit.path)
+		m.edit = nil
+	}
+}
+
+// readEdit loads the edited file. It reports false if nothing changed.
+func (s *editSession) readEdit() (bool, error) {
+	raw, err := os.ReadFile(s.path)
+	if err != nil {
+		return false, err
+	}
+	var kept []string
+	for _, l := range strings.Split(string(raw), "\n") {
+		if !strings.HasPrefix(l, "#") {
+			kept = append(kept, l)
+		}
+	}
+	content := strings.Join(kept, "\n")
+	s.text = content
+	if strings.TrimSpace(content) == strings.TrimSpace(s.original) {
+		return false, nil
+	}
+	var obj map[string]interface{}
+	if err := yaml.Unmarshal([]byte(content), &obj); err != nil {
+		s.edited = nil
+		return true, fmt.Errorf("invalid YAML: %v", err)
+	}
+	s.edited = obj
+	return true, nil
+}
+
+func fetchEditDiff(c *kubernetes.Clientset, s editSession) tea.Cmd {
+	return func() tea.Msg {
+		p, err := c.CoreV1().Pods(s.namespace).Get(context.TODO(), s.name, metav1.GetOptions{})
+		if err != nil {
+			return editDiffMsg{err: err}
+		}
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(p)
+		if err != nil {
+			return editDiffMsg{err: err}
+		}
+		obj["apiVersion"], obj["kind"] = "v1", "Pod"
+		server, err := editableYaml(obj)
+		if err != nil {
+			return editDiffMsg{err: err}
+		}
+		edited, err := editableYaml(s.edited)
+		if err != nil {
+			return editDiffMsg{err: err}
+		}
+		return editDiffMsg{diff: unifiedDiff(server, edited, "server", "edited")}
+	}
+}
+
+// applyEdit updates the pod with the edited object. With overwrite the
+// server's current resourceVersion is used, discarding concurrent changes.
+func applyEdit(c *kubernetes.Clientset, s editSession, overwrite bool) tea.Cmd {
+	return func() tea.Msg {
+		var pod corev1.Pod
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(s.edited, &pod); err != nil {
+			return editApplyMsg{err: err}
+		}
+		if overwrite {
+			cur, err := c.CoreV1().Pods(s.namespace).Get(context.TODO(), s.name, metav1.GetOptions{})
+			if err != nil {
+				return editApplyMsg{err: err}
+			}
+			pod.ResourceVersion = cur.ResourceVersion
+		}
+		_, err := c.CoreV1().Pods(s.namespace).Update(context.TODO(), &pod, metav1.UpdateOptions{FieldManager: "kube-pulse"})
+		return editApplyMsg{err: err}
+	}
+}
+
+// apiErrorText expands validation causes, which the plain error string
+// folds into one long line.
+func apiErrorText(err error) string {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) || status.Status().Details == nil || len(status.Status().Details.Causes) == 0 {
+		return err.Error()
+	}
+	var b strings.Builder
+	b.WriteString(status.Status().Message)
+	for _, c := range status.Status().Details.Causes {
+		b.WriteString(fmt.Sprintf("\n  * %s: %s", c.Field, c.Message))
+	}
+	return b.String()
+}
+
+func unifiedDiff(a, b, fromName, toName string) string {
+	d, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A: difflib.SplitLines(a), B: difflib.SplitLines(b),
+		FromFile: fromName, ToFile: toName, Context: 3,
+	})
+	return d
+}
+
+func colorDiff(d string) string {
+	lines := strings.Split(strings.TrimRight(d, "\n"), "\n")
+	for i, l := range lines {
+		switch {
+		case strings.HasPrefix(l, "+++") || strings.HasPrefix(l, "---"):
+			lines[i] = lipgloss.NewStyle().Foreground(cSecondary).Bold(true).Render(l)
+		case strings.HasPrefix(l, "+"):
+			lines[i] = lipgloss.NewStyle().Foreground(cGreen).Render(l)
+		case strings.HasPrefix(l, "-"):
+			lines[i] = lipgloss.NewStyle().Foreground(cRed).Render(l)
+		case strings.HasPrefix(l, "@@"):
+			lines[i] = lipgloss.NewStyle().Foreground(cCyan).Render(l)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// editContent is the diff screen: errors first, then the diff.
+func (m model) editContent() string {
+	if m.edit == nil {
+		return ""
+	}
+	var b strings.Builder
+	if m.edit.err != nil {
+		b.WriteString(lipgloss.NewStyle().Foreground(cRed).Bold(true).Render("[ERROR]") + "\n")
+		b.WriteString(lipgloss.NewStyle().Foreground(cRed).Render(apiErrorText(m.edit.err)) + "\n\n")
+	}
+	if m.edit.edited == nil {
+		return b.String()
+	}
+	if m.edit.diff == "" {
+		b.WriteString(lipgloss.NewStyle().Foreground(cDim).Render("No differences from the server copy."))
+	} else {
+		b.WriteString(colorDiff(m.edit.diff))
+	}
+	return b.String()
+}
+
+func (m model) applyConfirmView() string {
+	keys := "[y] Apply  [e] Edit again  [n] Discard"
+	if m.edit != nil && m.edit.conflict {
+		keys = "[u] Overwrite server changes  [e] Edit again  [n] Discard"
+	}
+	header := yamlHeaderStyle.Render(fmt.Sprintf(" [APPLY]: %s/%s ", m.edit.namespace, m.edit.name))
+	return "\n" + header + "\n\n" + m.viewport.View() + "\n\n" + footerStyle.Render("  "+keys) + "\n" + lipgloss.NewStyle().Foreground(cPrimary).Padding(0, 2).Render(m.msg)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// --- EVICT ---
+// Eviction goes through the policy/v1 subresource, so the API server
+// refuses it (429) when a PodDisruptionBudget has no disruptions left.
+type evictMsg struct {
+	pod string
+	pdb string // Blocking budgets, if the eviction was refused
+	err error
+}
+
+func evictPodCmd(c *kubernetes.Clientset, p PodInfo, raw *corev1.Pod) tea.Cmd {
+	return func() tea.Msg {
+		err := evictPod(context.TODO(), c, p.Namespace, p.Name, nil)
+		switch {
+		case err == nil:
+			return evictMsg{pod: p.Name}
+		case apierrors.IsTooManyRequests(err) && raw != nil:
+			return evictMsg{pod: p.Name, pdb: blockingPDBs(c, raw), err: err}
+		}
+		// Other failures may be the connection; apiErrMsg updates its state
+		return apiErrMsg{action: "Evict " + p.Name, err: err}
+	}
+}
+
+// blockingPDBs describes the budgets selecting the pod, with the numbers
+// that decide whether it may be disrupted.
+func blockingPDBs(c *kubernetes.Clientset, pod *corev1.Pod) string {
+	list, err := c.PolicyV1().PodDisruptionBudgets(pod.Namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return ""
+	}
+	var out []string
+	for _, pdb := range list.Items {
+		// A nil selector matches nothing; an empty one every pod in the namespace
+		if pdb.Spec.Selector == nil {
+			continue
+		}
+		sel, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || !sel.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		out = append(out, fmt.Sprintf("%s (disruptionsAllowed %d, healthy %d/%d)", pdb.Name, pdb.Status.DisruptionsAllowed, pdb.Status.CurrentHealthy, pdb.Status.DesiredHealthy))
+	}
+	return strings.Join(out, ", ")
+}
+
+func (m model) evictConfirmView() string {
+	box := modalStyle.BorderForeground(cOrange).Render(fmt.Sprintf("%s\n\nConfirm eviction of:\n%s\n\n%s\n\n%s / %s", lipgloss.NewStyle().Foreground(cOrange).Bold(true).Render("[!] EVICT POD"), lipgloss.NewStyle().Foreground(cSecondary).Render(m.podToDelete.Name), lipgloss.NewStyle().Foreground(cDim).Render("PodDisruptionBudgets are respected."), lipgloss.NewStyle().Foreground(cGreen).Bold(true).Render("[y] Confirm"), lipgloss.NewStyle().Foreground(cDim).Render("[n] Cancel")))
+	return strings.Repeat("\n", m.height/3) + lipgloss.PlaceHorizontal(m.width, lipgloss.Center, box)
+}
+
+// --- DELETE OPTIONS ---
+// deleteOptions are set from the delete modal. Force deletes with a zero
+// grace period, removing the pod from the API without waiting for the
+// kubelet, like `kubectl delete --force --grace-period=0`.
+type deleteOptions struct {
+	grace int64 // Seconds; -1 for the pod's own terminationGracePeriodSeconds
+	force bool
+}
+
+func (o deleteOptions) apiOptions() metav1.DeleteOptions {
+	var opts metav1.DeleteOptions
+	switch {
+	case o.force:
+		zero := int64(0)
+		opts.GracePeriodSeconds = &zero
+	case o.grace >= 0:
+		opts.GracePeriodSeconds = &o.grace
+	}
+	return opts
+}
+
+func (o deleteOptions) label() string {
+	grace := "pod default"
+	if o.grace >= 0 {
+		grace = fmt.Sprintf("%ds", o.grace)
+	}
+	if o.force {
+		return "Grace period: 0s  |  Force: ON (no graceful termination)"
+	}
+	return "Grace period: " + grace + "  |  Force: off"
+}
+
+func (m *model) submitGrace(value string) {
+	value = strings.TrimSuffix(strings.TrimSpace(value), "s")
+	if value == "" {
+		m.deleteOpts.grace = -1
+		return
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < 0 {
+		m.msg = fmt.Sprintf("Invalid grace period %q", value)
+		return
+	}
+	m.deleteOpts.grace = n
+}
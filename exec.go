@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/cancelreader"
+	"golang.org/x/term"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// --- EXEC ---
+// Shells run over the pod exec subresource. Like kubectl, WebSocket is
+// tried first with a fallback to SPDY for older API servers.
+func newExecutor(cfg *rest.Config, c *kubernetes.Clientset, namespace, pod, subresource string, opts runtimeOptions) (remotecommand.Executor, error) {
+	req := c.CoreV1().RESTClient().Post().Resource("pods").Namespace(namespace).Name(pod).SubResource(subresource)
+	if subresource == "attach" {
+		req = req.VersionedParams(&corev1.PodAttachOptions{Container: opts.container, Stdin: opts.stdin, Stdout: true, Stderr: !opts.tty, TTY: opts.tty}, scheme.ParameterCodec)
+	} else {
+		req = req.VersionedParams(&corev1.PodExecOptions{Container: opts.container, Command: opts.command, Stdin: opts.stdin, Stdout: true, Stderr: !opts.tty, TTY: opts.tty}, scheme.ParameterCodec)
+	}
+	spdyExec, err := remotecommand.NewSPDYExecutor(cfg, "POST", req.URL())
+	if err != nil {
+		return nil, err
+	}
+	wsExec, err := remotecommand.NewWebSocketExecutor(cfg, "GET", req.URL().String())
+	if err != nil {
+		return nil, err
+	}
+	return remotecommand.NewFallbackExecutor(wsExec, spdyExec, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+}
+
+type runtimeOptions struct {
+	container string
+	command   []string
+	stdin     bool
+	tty       bool
+}
+
+// detectShell probes for bash, then ash, then sh. API errors such as
+// Forbidden are returned straight away instead of trying the next shell.
+func detectShell(cfg *rest.Config, c *kubernetes.Clientset, namespace, pod, container string) (string, error) {
+	var lastErr error
+	for _, sh := range []string{"/bin/bash", "/bin/ash", "/bin/sh"} {
+		ex, err := newExecutor(cfg, c, namespace, pod, "exec", runtimeOptions{container: container, command: []string{sh, "-c", "exit 0"}})
+		if err != nil {
+			return "", err
+		}
+		err = ex.StreamWithContext(context.TODO(), remotecommand.StreamOptions{Stdout: io.Discard, Stderr: io.Discard})
+		if err == nil {
+			return sh, nil
+		}
+		var status apierrors.APIStatus
+		if errors.As(err, &status) {
+			return "", err
+		}
+		lastErr = err
+	}
+	return "", fmt.Errorf("no shell found in %s (tried bash, ash, sh): %v", container, lastErr)
+}
+
+// ttySession is an interactive exec or attach. It implements
+// tea.ExecCommand so the TUI is suspended while it runs.
+type ttySession struct {
+	executor remotecommand.Executor
+	hint     string // Printed before streaming starts
+	stdin    io.Reader
+	stdout   io.Writer
+}
+
+func (s *ttySession) SetStdin(r io.Reader)  { s.stdin = r }
+func (s *ttySession) SetStdout(w io.Writer) { s.stdout = w }
+func (s *ttySession) SetStderr(io.Writer)   {} // A TTY merges stderr into stdout
+
+func (s *ttySession) Run() error {
+	in, ok := s.stdin.(*os.File)
+	if !ok {
+		in = os.Stdin
+	}
+	fd := int(in.Fd())
+	if term.IsTerminal(fd) {
+		if old, err := term.MakeRaw(fd); err == nil {
+			defer term.Restore(fd, old)
+		}
+	}
+	// The stdin copier would otherwise keep the first key pressed after
+	// the session ends.
+	stdin, err := cancelreader.NewReader(in)
+	if err != nil {
+		return err
+	}
+	defer stdin.Cancel()
+
+	if s.hint != "" {
+		io.WriteString(s.stdout, s.hint)
+	}
+	sizes := newSizeQueue(int(os.Stdout.Fd()))
+	defer sizes.stop()
+	return s.executor.StreamWithContext(context.Background(), remotecommand.StreamOptions{
+		Stdin: stdin, Stdout: s.stdout, Tty: true, TerminalSizeQueue: sizes,
+	})
+}
+
+// sizeQueue feeds local terminal sizes to the remote TTY.
+type sizeQueue struct {
+	ch     chan remotecommand.TerminalSize
+	stopCh chan struct{}
+}
+
+func (q *sizeQueue) Next() *remotecommand.TerminalSize {
+	s, ok := <-q.ch
+	if !ok {
+		return nil
+	}
+	return &s
+}
+
+func (q *sizeQueue) stop() { close(q.stopCh) }
+
+// push queues the current size, replacing one not yet consumed.
+func (q *sizeQueue) push(fd int) {
+	w, h, err := term.GetSize(fd)
+	if err != nil {
+		return
+	}
+	size := remotecommand.TerminalSize{Width: uint16(w), Height: uint16(h)}
+	select {
+	case <-q.ch:
+	default:
+	}
+	q.ch <- size
+}
+
+type shellReadyMsg struct {
+	what    string // "Shell" or "Debug"
+	pod     string
+	session *ttySession
+}
+
+type execDoneMsg struct {
+	what string
+	err  error
+}
+
+// openShell detects a shell in the background, so failures land in the
+// status bar instead of flashing a suspended terminal.
+func openShell(cfg *rest.Config, c *kubernetes.Clientset, namespace, pod, container string) tea.Cmd {
+	return func() tea.Msg {
+		sh, err := detectShell(cfg, c, namespace, pod, container)
+		if err != nil {
+			return execDoneMsg{what: "Shell", err: err}
+		}
+		ex, err := newExecutor(cfg, c, namespace, pod, "exec", runtimeOptions{container: container, command: []string{sh}, stdin: true, tty: true})
+		if err != nil {
+			return execDoneMsg{what: "Shell", err: err}
+		}
+		return shellReadyMsg{what: "Shell", pod: pod, session: &ttySession{executor: ex}}
+	}
+}
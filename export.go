@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// --- SAVE TO DISK ---
+type saveMsg string
+
+// saveFileName fills the name template for the active viewer:
+// <ns>_<pod>_<container>_<timestamp>.log for logs, and the same without the
+// container for YAML and diagnosis reports.
+func (m model) saveFileName() string {
+	stamp := time.Now().Format("20060102-150405")
+	var parts []string
+	ext := ".txt"
+	switch m.state {
+	case viewLogs:
+		ext = ".log"
+		if m.logStream != nil && m.logStream.tail != nil {
+			parts = []string{m.logStream.tail.namespace, "tail", m.logStream.tail.label}
+		} else {
+			parts = []string{m.selectedPod.Namespace, m.selectedPod.Name, m.logContainer}
+			if m.logPrevious {
+				parts = append(parts, "previous")
+			}
+		}
+	case viewYaml:
+		ext = m.yamlExt()
+		parts = []string{m.selectedPod.Namespace, m.selectedPod.Name}
+	case viewDiagnosis:
+		parts = []string{m.selectedPod.Namespace, m.selectedPod.Name, "diagnosis"}
+	}
+	var clean []string
+	for _, p := range append(parts, stamp) {
+		if p == "" {
+			continue
+		}
+		// Keep a portable set; selectors and tail labels bring "=", "/", "!"...
+		clean = append(clean, strings.Map(func(r rune) rune {
+			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '.' || r == '-' || r == '_' {
+				return r
+			}
+			return '-'
+		}, p))
+	}
+	return strings.Join(clean, "_") + ext
+}
+
+// saveBuffer writes the viewer's raw buffer, with lipgloss styling
+// stripped, to path. An existing file is never overwritten.
+func saveBuffer(path, content string) tea.Cmd {
+	return func() tea.Msg {
+		plain := ansi.Strip(content)
+		if !strings.HasSuffix(plain, "\n") {
+			plain += "\n"
+		}
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, fs.ErrExist) {
+			return saveMsg(fmt.Sprintf("Save failed: %s: file exists", path))
+		}
+		if err != nil {
+			return saveMsg(fmt.Sprintf("Save failed: %v", err))
+		}
+		_, err = f.WriteString(plain)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return saveMsg(fmt.Sprintf("Save failed: %v", err))
+		}
+		return saveMsg(fmt.Sprintf("Saved %d lines to %s", strings.Count(plain, "\n"), path))
+	}
+}
+
+// saveContent is the buffer the save action writes for the active viewer.
+func (m model) saveContent() string {
+	switch m.state {
+	case viewLogs:
+		return m.logContent
+	case viewYaml:
+		return m.yamlContent
+	case viewDiagnosis:
+		return m.diagContent
+	}
+	return ""
+}
@@ -0,0 +1,343 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// --- PORT FORWARDING ---
+type fwdState int
+
+const (
+	fwdStarting fwdState = iota
+	fwdReady
+	fwdError
+)
+
+// forwarder is a handle on one client-go port-forward. Its state is only
+// mutated from Update, driven by fwdStatusMsg.
+type forwarder struct {
+	id         int
+	Namespace  string
+	Pod        string
+	LocalPort  uint16 // 0 until ready; picked by the OS
+	RemotePort int32
+	State      fwdState
+	Err        error
+	Started    time.Time
+
+	bytesIn  atomic.Int64 // Pod -> local
+	bytesOut atomic.Int64 // Local -> pod
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	done     chan struct{} // Closed once the local listener is released
+}
+
+type fwdStatusMsg struct {
+	key       string
+	id        int
+	state     fwdState
+	localPort uint16
+	err       error
+}
+
+func (f *forwarder) Stop() {
+	f.stopOnce.Do(func() { close(f.stopCh) })
+}
+
+// startForward dials the pod's portforward subresource and reports state
+// changes on events. Local port 0 lets the OS pick a free port, so several
+// forwards never collide. A non-nil prev is waited on so its local port can
+// be reused.
+func startForward(cfg *rest.Config, c *kubernetes.Clientset, key string, id int, p PodInfo, local uint16, remote int32, prev *forwarder, events chan<- fwdStatusMsg) *forwarder {
+	f := &forwarder{id: id, Namespace: p.Namespace, Pod: p.Name, RemotePort: remote, State: fwdStarting, Started: time.Now(), stopCh: make(chan struct{}), done: make(chan struct{})}
+	send := func(s fwdStatusMsg) {
+		s.key, s.id = key, id
+		events <- s
+	}
+
+	go func() {
+		defer close(f.done)
+		if prev != nil {
+			select {
+			case <-prev.done:
+			case <-time.After(5 * time.Second):
+			}
+		}
+		transport, upgrader, err := spdy.RoundTripperFor(cfg)
+		if err != nil {
+			send(fwdStatusMsg{state: fwdError, err: err})
+			return
+		}
+		url := c.CoreV1().RESTClient().Post().Resource("pods").Namespace(p.Namespace).Name(p.Name).SubResource("portforward").URL()
+		dialer := countingDialer{spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url), f}
+
+		readyCh := make(chan struct{})
+		pf, err := portforward.NewOnAddresses(dialer, []string{"localhost"}, []string{fmt.Sprintf("%d:%d", local, remote)}, f.stopCh, readyCh, io.Discard, io.Discard)
+		if err != nil {
+			send(fwdStatusMsg{state: fwdError, err: err})
+			return
+		}
+
+		go func() {
+			select {
+			case <-readyCh:
+				if ports, err := pf.GetPorts(); err == nil && len(ports) > 0 {
+					send(fwdStatusMsg{state: fwdReady, localPort: ports[0].Local})
+				}
+			case <-f.stopCh:
+			}
+		}()
+
+		err = pf.ForwardPorts()
+		select {
+		case <-f.stopCh: // Stopped by us
+		default:
+			if err == nil {
+				err = fmt.Errorf("forward closed")
+			}
+			send(fwdStatusMsg{state: fwdError, err: err})
+		}
+	}()
+	return f
+}
+
+func waitForForwardStatus(events <-chan fwdStatusMsg) tea.Cmd {
+	return func() tea.Msg { return <-events }
+}
+
+// fwdLabel is the FWD column text for a forward.
+func (f *forwarder) fwdLabel() string {
+	switch f.State {
+	case fwdReady:
+		return fmt.Sprintf("● %d", f.LocalPort)
+	case fwdError:
+		return "✖ err"
+	default:
+		return "○ ..."
+	}
+}
+
+// --- TRAFFIC ACCOUNTING ---
+// The forwarder copies between local sockets and SPDY streams internally,
+// so bytes are counted by wrapping the streams it creates.
+type countingDialer struct {
+	httpstream.Dialer
+	f *forwarder
+}
+
+func (d countingDialer) Dial(protocols ...string) (httpstream.Connection, string, error) {
+	conn, proto, err := d.Dialer.Dial(protocols...)
+	if err != nil {
+		return nil, proto, err
+	}
+	return countingConn{conn, d.f}, proto, nil
+}
+
+type countingConn struct {
+	httpstream.Connection
+	f *forwarder
+}
+
+func (c countingConn) CreateStream(headers http.Header) (httpstream.Stream, error) {
+	s, err := c.Connection.CreateStream(headers)
+	if err != nil {
+		return nil, err
+	}
+	return countingStream{s, c.f}, nil
+}
+
+type countingStream struct {
+	httpstream.Stream
+	f *forwarder
+}
+
+func (s countingStream) Read(p []byte) (int, error) {
+	n, err := s.Stream.Read(p)
+	s.f.bytesIn.Add(int64(n))
+	return n, err
+}
+
+func (s countingStream) Write(p []byte) (int, error) {
+	n, err := s.Stream.Write(p)
+	s.f.bytesOut.Add(int64(n))
+	return n, err
+}
+
+// --- FORWARD MANAGER VIEW ---
+type fwdTickMsg time.Time
+
+// The manager shows live uptime and traffic, so it redraws on its own tick.
+func fwdTick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg { return fwdTickMsg(t) })
+}
+
+func (m model) forwardKeys() []string {
+	var keys []string
+	for k := range m.activeForwards {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (m model) updateForwards(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	keys := m.forwardKeys()
+	switch msg.String() {
+	case "esc", "q", "F":
+		m.state = viewList
+		m.msg = "Dashboard"
+	case "up", "k":
+		if m.fwdCursor > 0 {
+			m.fwdCursor--
+		}
+	case "down", "j":
+		if m.fwdCursor < len(keys)-1 {
+			m.fwdCursor++
+		}
+	case "x", "d":
+		if len(keys) > 0 {
+			key := keys[m.fwdCursor]
+			m.activeForwards[key].Stop()
+			delete(m.activeForwards, key)
+			if m.fwdCursor >= len(keys)-1 && m.fwdCursor > 0 {
+				m.fwdCursor--
+			}
+			m.msg = fmt.Sprintf("Stopped forwarding %s", key)
+		}
+	case "r":
+		if len(keys) > 0 {
+			key := keys[m.fwdCursor]
+			f := m.activeForwards[key]
+			m.restartForward(key, f.Pod, f.LocalPort, f.RemotePort)
+			m.msg = fmt.Sprintf("Restarting forward %s...", key)
+		}
+	case "t":
+		if len(keys) > 0 {
+			f := m.activeForwards[keys[m.fwdCursor]]
+			m.openPrompt("retarget", "pod:port", fmt.Sprintf("%s:%d", f.Pod, f.RemotePort))
+			return m, textinput.Blink
+		}
+	}
+	return m, nil
+}
+
+// restartForward replaces the forward at key with one to pod:remote,
+// keeping the local port so bookmarked URLs keep working.
+func (m *model) restartForward(key, pod string, local uint16, remote int32) {
+	old := m.activeForwards[key]
+	old.Stop()
+	delete(m.activeForwards, key)
+	target := PodInfo{Namespace: old.Namespace, Name: pod}
+	newKey := target.Namespace + "/" + target.Name
+	m.fwdSeq++
+	m.activeForwards[newKey] = startForward(m.restConfig, m.client, newKey, m.fwdSeq, target, local, remote, old, m.fwdEvents)
+}
+
+// retargetForward parses "pod:port", "pod" or ":port" and points the
+// selected forward at the new target.
+func (m *model) retargetForward(input string) {
+	keys := m.forwardKeys()
+	if len(keys) == 0 {
+		return
+	}
+	key := keys[m.fwdCursor]
+	f := m.activeForwards[key]
+	pod, remote := f.Pod, f.RemotePort
+	name, portStr, hasPort := strings.Cut(strings.TrimSpace(input), ":")
+	if name != "" {
+		pod = name
+	}
+	if hasPort {
+		p, err := strconv.Atoi(portStr)
+		if err != nil || p <= 0 || p > 65535 {
+			m.msg = fmt.Sprintf("Invalid port %q", portStr)
+			return
+		}
+		remote = int32(p)
+	}
+	newKey := f.Namespace + "/" + pod
+	if _, exists := m.activeForwards[newKey]; exists && newKey != key {
+		m.msg = fmt.Sprintf("%s is already forwarded", newKey)
+		return
+	}
+	if !m.podExists(newKey) {
+		m.msg = fmt.Sprintf("Pod %s not found", newKey)
+		return
+	}
+	m.restartForward(key, pod, f.LocalPort, remote)
+	m.msg = fmt.Sprintf("Re-targeting :%d -> %s:%d...", f.LocalPort, pod, remote)
+}
+
+func (m model) forwardsView() string {
+	keys := m.forwardKeys()
+	var b bytes.Buffer
+	w := tabwriter.NewWriter(&b, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n", "NAMESPACE", "POD", "LOCAL", "REMOTE", "STATE", "UPTIME", "IN", "OUT")
+	for _, k := range keys {
+		f := m.activeForwards[k]
+		local := "-"
+		if f.LocalPort > 0 {
+			local = fmt.Sprintf("%d", f.LocalPort)
+		}
+		state := f.fwdLabel()
+		if f.State == fwdError && f.Err != nil {
+			state = "✖ " + truncate(f.Err.Error(), 40)
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\t\n", truncate(f.Namespace, 25), truncate(f.Pod, 55), local, f.RemotePort, state, shortAge(time.Since(f.Started)), humanBytes(f.bytesIn.Load()), humanBytes(f.bytesOut.Load()))
+	}
+	w.Flush()
+
+	lines := strings.Split(b.String(), "\n")
+	rows := colHeadStyle.Render(lines[0]) + "\n"
+	for i := range keys {
+		line := lines[i+1]
+		style := lipgloss.NewStyle().Foreground(cSecondary)
+		if i == m.fwdCursor {
+			style = selectedRowStyle
+		} else if m.activeForwards[keys[i]].State == fwdError {
+			style = style.Foreground(cRed)
+		}
+		rows += style.Render(line) + "\n"
+	}
+	if len(keys) == 0 {
+		rows += lipgloss.NewStyle().Foreground(cDim).Render("  No active forwards. Press [f] on a pod to start one.") + "\n"
+	}
+
+	bottom := footerStyle.Render("  [x] Stop  [r] Restart  [t] Re-target  [Esc] Back") + "\n" + lipgloss.NewStyle().Foreground(cPrimary).Padding(0, 2).Render(m.msg)
+	if m.promptAction != "" {
+		bottom = m.promptView() + "\n"
+	}
+	return "\n" + headerStyle.Render(" PORT FORWARDS ") + "\n\n" + rows + "\n" + bottom
+}
+
+func humanBytes(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1fGi", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1fMi", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1fKi", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%dB", n)
+}
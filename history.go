@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+// --- ROLLOUT HISTORY ---
+// A Deployment's history is its ReplicaSets: each keeps the pod template
+// of one revision. Rolling back copies that template onto the Deployment,
+// as `kubectl rollout undo` does.
+const (
+	revisionAnnotation    = "deployment.kubernetes.io/revision"
+	changeCauseAnnotation = "kubernetes.io/change-cause"
+)
+
+type revisionInfo struct {
+	Revision    int64
+	ReplicaSet  string
+	Images      string
+	ChangeCause string
+	Age         string
+	Replicas    int32
+	Current     bool
+	template    corev1.PodTemplateSpec // Without the pod-template-hash label
+}
+
+type rolloutHistory struct {
+	namespace, name string
+	revisions       []revisionInfo
+	cursor          int
+	target          *revisionInfo // Rollback awaiting confirmation
+	diff            string
+	ret             sessionState // View to go back to
+}
+
+type historyTargetMsg struct {
+	owner workloadRef
+	err   error
+}
+
+// resolveHistoryTarget finds the Deployment owning the pod.
+func resolveHistoryTarget(c *kubernetes.Clientset, raw *corev1.Pod) tea.Cmd {
+	return func() tea.Msg {
+		owner, err := resolveOwner(c, raw)
+		if err == nil && owner.Kind != "Deployment" {
+			err = fmt.Errorf("%s is not a Deployment; only Deployments keep a rollout history", owner)
+		}
+		return historyTargetMsg{owner: owner, err: err}
+	}
+}
+
+func templateWithoutHash(t corev1.PodTemplateSpec) corev1.PodTemplateSpec {
+	t = *t.DeepCopy()
+	delete(t.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+	return t
+}
+
+// openHistory loads the revisions from the informer cache.
+func (m *model) openHistory(namespace, name string) {
+	ret := m.state
+	if m.history != nil {
+		ret = m.history.ret
+	}
+	m.history = &rolloutHistory{namespace: namespace, name: name, ret: ret}
+	m.loadHistory()
+	m.state = viewHistory
+	m.msg = fmt.Sprintf("%d revisions of %s", len(m.history.revisions), name)
+}
+
+func (m *model) loadHistory() {
+	h := m.history
+	d, err := m.watcher.deployments.Deployments(h.namespace).Get(h.name)
+	if err != nil {
+		h.revisions = nil
+		return
+	}
+	current := templateWithoutHash(d.Spec.Template)
+	var revs []revisionInfo
+	for _, rs := range m.watcher.listReplicaSets(d.Namespace) {
+		if ref := metav1.GetControllerOf(rs); ref == nil || ref.UID != d.UID {
+			continue
+		}
+		rev, _ := strconv.ParseInt(rs.Annotations[revisionAnnotation], 10, 64)
+		t := templateWithoutHash(rs.Spec.Template)
+		revs = append(revs, revisionInfo{
+			Revision: rev, ReplicaSet: rs.Name, Images: containerImages(t.Spec), ChangeCause: rs.Annotations[changeCauseAnnotation],
+			Age: shortAge(time.Since(rs.CreationTimestamp.Time)), Replicas: rs.Status.Replicas, template: t,
+			Current: templatesEqual(t, current),
+		})
+	}
+	sort.Slice(revs, func(i, j int) bool { return revs[i].Revision > revs[j].Revision })
+	h.revisions = revs
+	if h.cursor >= len(revs) {
+		h.cursor = max(len(revs)-1, 0)
+	}
+}
+
+func templateYaml(t corev1.PodTemplateSpec) string {
+	out, err := yaml.Marshal(t)
+	if err != nil {
+		return err.Error()
+	}
+	return string(out)
+}
+
+func templatesEqual(a, b corev1.PodTemplateSpec) bool {
+	return templateYaml(a) == templateYaml(b)
+}
+
+// prepareRollback diffs the live template against the selected revision.
+func (m *model) prepareRollback() {
+	h := m.history
+	if len(h.revisions) == 0 {
+		return
+	}
+	rev := h.revisions[h.cursor]
+	if rev.Current {
+		m.msg = fmt.Sprintf("Revision %d is the current template.", rev.Revision)
+		return
+	}
+	d, err := m.watcher.deployments.Deployments(h.namespace).Get(h.name)
+	if err != nil {
+		m.msg = fmt.Sprintf("Rollback failed: %v", err)
+		return
+	}
+	h.target = &rev
+	h.diff = unifiedDiff(templateYaml(templateWithoutHash(d.Spec.Template)), templateYaml(rev.template), "current", fmt.Sprintf("revision %d", rev.Revision))
+	m.state = viewRollbackConfirm
+	m.viewport.GotoTop()
+	m.refreshViewport()
+}
+
+// rollbackDeployment writes the revision's template and change-cause onto
+// the Deployment, which starts a normal rollout. A revision without a
+// change-cause clears it, as kubectl rollout undo does.
+func rollbackDeployment(c *kubernetes.Clientset, namespace, name string, rev revisionInfo) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.TODO()
+		d, err := c.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return rolloutStartedMsg{watch: rolloutWatch{verb: "Rollback"}, err: err}
+		}
+		d.Spec.Template = rev.template
+		if rev.ChangeCause == "" {
+			delete(d.Annotations, changeCauseAnnotation)
+		} else {
+			if d.Annotations == nil {
+				d.Annotations = map[string]string{}
+			}
+			d.Annotations[changeCauseAnnotation] = rev.ChangeCause
+		}
+		d, err = c.AppsV1().Deployments(namespace).Update(ctx, d, metav1.UpdateOptions{FieldManager: "kube-pulse"})
+		if err != nil {
+			return rolloutStartedMsg{watch: rolloutWatch{verb: "Rollback"}, err: err}
+		}
+		return rolloutStartedMsg{watch: rolloutWatch{verb: "Rollback", kind: "Deployment", namespace: namespace, name: name, generation: d.Generation}}
+	}
+}
+
+func (m model) updateHistory(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	h := m.history
+	switch msg.String() {
+	case "esc", "q":
+		m.state = h.ret
+		m.history = nil
+	case "up", "k":
+		if h.cursor > 0 {
+			h.cursor--
+		}
+	case "down", "j":
+		if h.cursor < len(h.revisions)-1 {
+			h.cursor++
+		}
+	case "enter", "u":
+		m.prepareRollback()
+	}
+	return m, nil
+}
+
+func (m model) updateRollbackConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	h := m.history
+	switch msg.String() {
+	case "y", "Y":
+		m.msg = fmt.Sprintf("Rolling %s back to revision %d...", h.name, h.target.Revision)
+		cmd = rollbackDeployment(m.client, h.namespace, h.name, *h.target)
+		h.target = nil
+		m.state = viewHistory
+		return m, cmd
+	case "n", "N", "esc", "q":
+		h.target = nil
+		m.state = viewHistory
+		m.msg = "Rollback cancelled."
+	default:
+		m.viewport, cmd = m.viewport.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m model) historyView() string {
+	h := m.history
+	var s strings.Builder
+	s.WriteString(headerStyle.Render(fmt.Sprintf(" ROLLOUT HISTORY: Deployment/%s ", h.name)) + "\n\n")
+	if len(h.revisions) == 0 {
+		s.WriteString(lipgloss.NewStyle().Foreground(cDim).Render("No revisions found.") + "\n")
+	} else {
+		s.WriteString(colHeadStyle.Render(fmt.Sprintf("  %-5s %-4s %-5s %-40s %s", "REV", "PODS", "AGE", "IMAGES", "CHANGE-CAUSE")) + "\n")
+	}
+	for i, r := range h.revisions {
+		cause := r.ChangeCause
+		if cause == "" {
+			cause = "<none>"
+		}
+		line := fmt.Sprintf("%-5d %-4d %-5s %-40s %s", r.Revision, r.Replicas, r.Age, truncate(r.Images, 40), truncate(cause, 50))
+		style := lipgloss.NewStyle().Foreground(cSecondary)
+		cursor := "  "
+		if r.Current {
+			style = style.Foreground(cGreen)
+			line += "  (current)"
+		}
+		if i == h.cursor {
+			cursor = "> "
+			style = selectedRowStyle
+		}
+		s.WriteString(style.Render(cursor+line) + "\n")
+	}
+	s.WriteString(footerStyle.Render("\n[Enter] Roll back to revision  [Esc] Back") + "\n")
+	s.WriteString(lipgloss.NewStyle().Foreground(cPrimary).Render(m.msg))
+	box := modalStyle.BorderForeground(cPrimary).Align(lipgloss.Left).Render(s.String())
+	return strings.Repeat("\n", m.height/6) + lipgloss.PlaceHorizontal(m.width, lipgloss.Center, box)
+}
+
+func (m model) rollbackConfirmView() string {
+	h := m.history
+	header := yamlHeaderStyle.Render(fmt.Sprintf(" [ROLLBACK]: Deployment/%s → revision %d ", h.name, h.target.Revision))
+	return "\n" + header + "\n\n" + m.viewport.View() + "\n\n" + footerStyle.Render("  [y] Roll back  [n] Cancel") + "\n" + lipgloss.NewStyle().Foreground(cPrimary).Padding(0, 2).Render(m.msg)
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	listersappsv1 "k8s.io/client-go/listers/apps/v1"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// --- SHARED INFORMERS ---
+// clusterWatcher keeps pods, nodes, namespaces and workloads in a local
+// cache and pushes change notifications into the Bubble Tea program as
+// messages.
+type clusterWatcher struct {
+	factory      informers.SharedInformerFactory
+	pods         listersv1.PodLister
+	nodes        listersv1.NodeLister
+	nsList       listersv1.NamespaceLister
+	deployments  listersappsv1.DeploymentLister
+	statefulSets listersappsv1.StatefulSetLister
+	daemonSets   listersappsv1.DaemonSetLister
+	replicaSets  listersappsv1.ReplicaSetLister // Links Deployment pods to their owner
+
+	podSynced      cache.InformerSynced
+	podKeys        chan string   // "namespace/name" of every changed pod
+	nodesDirty     chan struct{} // Coalesced: one pending signal is enough
+	nsDirty        chan struct{}
+	workloadsDirty chan struct{}
+	errs           chan error // List and watch failures, see watchError
+	stop           chan struct{}
+}
+
+func newClusterWatcher(c kubernetes.Interface) *clusterWatcher {
+	f := informers.NewSharedInformerFactory(c, 0)
+	w := &clusterWatcher{
+		factory:        f,
+		podKeys:        make(chan string, 1024),
+		nodesDirty:     make(chan struct{}, 1),
+		nsDirty:        make(chan struct{}, 1),
+		workloadsDirty: make(chan struct{}, 1),
+		errs:           make(chan error, 1),
+		stop:           make(chan struct{}),
+	}
+
+	podInf := f.Core().V1().Pods()
+	podInf.Informer().AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+		// The initial list is delivered as one snapshot once synced
+		AddFunc: func(obj interface{}, initial bool) {
+			if !initial {
+				w.pushPod(obj)
+			}
+		},
+		UpdateFunc: func(_, obj interface{}) { w.pushPod(obj) },
+		DeleteFunc: func(obj interface{}) { w.pushPod(obj) },
+	})
+	w.pods = podInf.Lister()
+	w.podSynced = podInf.Informer().HasSynced
+
+	nodeInf := f.Core().V1().Nodes()
+	nodeInf.Informer().AddEventHandler(signalHandler(w.nodesDirty))
+	w.nodes = nodeInf.Lister()
+
+	nsInf := f.Core().V1().Namespaces()
+	nsInf.Informer().AddEventHandler(signalHandler(w.nsDirty))
+	w.nsList = nsInf.Lister()
+
+	apps := f.Apps().V1()
+	apps.Deployments().Informer().AddEventHandler(signalHandler(w.workloadsDirty))
+	apps.StatefulSets().Informer().AddEventHandler(signalHandler(w.workloadsDirty))
+	apps.DaemonSets().Informer().AddEventHandler(signalHandler(w.workloadsDirty))
+	w.deployments = apps.Deployments().Lister()
+	w.statefulSets = apps.StatefulSets().Lister()
+	w.daemonSets = apps.DaemonSets().Lister()
+	apps.ReplicaSets().Informer().AddEventHandler(signalHandler(w.workloadsDirty))
+	w.replicaSets = apps.ReplicaSets().Lister()
+
+	for _, inf := range []cache.SharedIndexInformer{podInf.Informer(), nodeInf.Informer(), nsInf.Informer(),
+		apps.Deployments().Informer(), apps.StatefulSets().Informer(), apps.DaemonSets().Informer(), apps.ReplicaSets().Informer()} {
+		inf.SetWatchErrorHandler(w.watchError)
+	}
+	return w
+}
+
+func (w *clusterWatcher) Start() { w.factory.Start(w.stop) }
+
+func (w *clusterWatcher) Stop() {
+	close(w.stop)
+	w.factory.Shutdown()
+}
+
+func (w *clusterWatcher) pushPod(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return
+	}
+	select {
+	case w.podKeys <- key:
+	case <-w.stop:
+	}
+}
+
+func signalHandler(ch chan struct{}) cache.ResourceEventHandlerFuncs {
+	notify := func() {
+		select {
+		case ch <- struct{}{}:
+		default: // Already pending
+		}
+	}
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { notify() },
+		UpdateFunc: func(_, _ interface{}) { notify() },
+		DeleteFunc: func(interface{}) { notify() },
+	}
+}
+
+// --- WATCH COMMANDS ---
+type podsSyncedMsg struct{}
+type podEventsMsg []string
+type nodesChangedMsg struct{}
+type nsChangedMsg struct{}
+type workloadsChangedMsg struct{}
+
+func (w *clusterWatcher) waitForPodSync() tea.Cmd {
+	return func() tea.Msg {
+		if !cache.WaitForCacheSync(w.stop, w.podSynced) {
+			return nil
+		}
+		return podsSyncedMsg{}
+	}
+}
+
+// waitForPodEvents blocks for one change, then drains whatever else is
+// queued so bursts (rollouts, node failures) cost a single re-filter.
+func (w *clusterWatcher) waitForPodEvents() tea.Cmd {
+	return func() tea.Msg {
+		var keys []string
+		select {
+		case k := <-w.podKeys:
+			keys = append(keys, k)
+		case <-w.stop:
+			return nil
+		}
+		for len(keys) < 512 {
+			select {
+			case k := <-w.podKeys:
+				keys = append(keys, k)
+			default:
+				return podEventsMsg(keys)
+			}
+		}
+		return podEventsMsg(keys)
+	}
+}
+
+func (w *clusterWatcher) waitForNodes() tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case <-w.nodesDirty:
+			return nodesChangedMsg{}
+		case <-w.stop:
+			return nil
+		}
+	}
+}
+
+func (w *clusterWatcher) waitForNamespaces() tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case <-w.nsDirty:
+			return nsChangedMsg{}
+		case <-w.stop:
+			return nil
+		}
+	}
+}
+
+func (w *clusterWatcher) waitForWorkloads() tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case <-w.workloadsDirty:
+			return workloadsChangedMsg{}
+		case <-w.stop:
+			return nil
+		}
+	}
+}
+
+// --- CACHE READS ---
+func (w *clusterWatcher) listPods() []*corev1.Pod {
+	l, _ := w.pods.List(labels.Everything())
+	return l
+}
+
+func (w *clusterWatcher) getPod(key string) (*corev1.Pod, bool) {
+	ns, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, false
+	}
+	p, err := w.pods.Pods(ns).Get(name)
+	if err != nil {
+		return nil, false
+	}
+	return p, true
+}
+
+func (w *clusterWatcher) listNodes() []*corev1.Node {
+	l, _ := w.nodes.List(labels.Everything())
+	return l
+}
+
+func (w *clusterWatcher) listNamespaces() []string {
+	l, _ := w.nsList.List(labels.Everything())
+	var n []string
+	for _, i := range l {
+		n = append(n, i.Name)
+	}
+	return n
+}
+
+func (w *clusterWatcher) listDeployments() []*appsv1.Deployment {
+	l, _ := w.deployments.List(labels.Everything())
+	return l
+}
+
+func (w *clusterWatcher) listStatefulSets() []*appsv1.StatefulSet {
+	l, _ := w.statefulSets.List(labels.Everything())
+	return l
+}
+
+func (w *clusterWatcher) listDaemonSets() []*appsv1.DaemonSet {
+	l, _ := w.daemonSets.List(labels.Everything())
+	return l
+}
+
+func (w *clusterWatcher) listReplicaSets(namespace string) []*appsv1.ReplicaSet {
+	l, _ := w.replicaSets.ReplicaSets(namespace).List(labels.Everything())
+	return l
+}
+
+// ownerUIDs is the set of controller UIDs whose pods belong to the workload:
+// the workload itself plus, for a Deployment, each of its ReplicaSets.
+func (w *clusterWatcher) ownerUIDs(kind, namespace string, uid types.UID) map[types.UID]bool {
+	uids := map[types.UID]bool{uid: true}
+	if kind != "Deployment" {
+		return uids
+	}
+	rs, _ := w.replicaSets.ReplicaSets(namespace).List(labels.Everything())
+	for _, r := range rs {
+		if ref := metav1.GetControllerOf(r); ref != nil && ref.UID == uid {
+			uids[r.UID] = true
+		}
+	}
+	return uids
+}
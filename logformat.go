@@ -0,0 +1,346 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- STRUCTURED LOGS ---
+// Each log line is parsed once on arrival: JSON first, then logfmt, then
+// plain text. viewLogs renders the parsed entries as columns.
+const (
+	levelUnknown = iota
+	levelDebug
+	levelInfo
+	levelWarn
+	levelError
+)
+
+var levelNames = []string{"all", "debug", "info", "warn", "error"}
+
+var (
+	tsKeys    = []string{"ts", "time", "timestamp", "@timestamp", "t"}
+	levelKeys = []string{"level", "lvl", "severity", "@level", "log.level"}
+	msgKeys   = []string{"msg", "message", "@message", "log"}
+
+	plainLevelRe = regexp.MustCompile(`\b(?i:(fatal|panic|error|err|warn|warning|info|debug|trace))\b`)
+)
+
+type logEntry struct {
+	tag    string // Styled tail prefix, if any
+	format string // "json", "logfmt" or "" for plain text
+	ts     string
+	level  int
+	msg    string
+	fields map[string]string
+	raw    string // Line without the tag
+
+	shown    int    // level, or the level inherited from the line above
+	rendered string // Cached column layout; redone when the fields change
+}
+
+// logRender holds the viewLogs display options.
+type logRender struct {
+	raw        bool     // Show lines exactly as received
+	minLevel   int      // Hide entries below this level
+	fields     []string // Extra keys shown as columns, in pick order
+	pickKeys   []string // Keys offered by the picker
+	pickCursor int
+}
+
+func parseLogLine(l logLine) logEntry {
+	body := l.text
+	e := logEntry{tag: l.tag, raw: body}
+	if kv, ok := parseJSONLine(body); ok {
+		e.format, e.fields = "json", kv
+	} else if kv, ok := parseLogfmt(body); ok {
+		e.format, e.fields = "logfmt", kv
+	} else {
+		e.msg = body
+		if m := plainLevelRe.FindStringSubmatch(body); m != nil {
+			e.level = normalizeLevel(m[1])
+		}
+		return e
+	}
+	e.ts = formatLogTime(takeField(e.fields, tsKeys))
+	e.level = normalizeLevel(takeField(e.fields, levelKeys))
+	e.msg = takeField(e.fields, msgKeys)
+	return e
+}
+
+func parseJSONLine(s string) (map[string]string, bool) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "{") {
+		return nil, false
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &obj); err != nil {
+		return nil, false
+	}
+	kv := make(map[string]string, len(obj))
+	for k, v := range obj {
+		switch v := v.(type) {
+		case string:
+			kv[k] = v
+		case float64:
+			kv[k] = strconv.FormatFloat(v, 'f', -1, 64)
+		case nil:
+			kv[k] = "null"
+		default:
+			b, _ := json.Marshal(v)
+			kv[k] = string(b)
+		}
+	}
+	return kv, true
+}
+
+// parseLogfmt accepts lines made of key=value pairs, with optional quoted
+// values. At least two pairs and a recognised key are required so that
+// prose containing a stray "=" stays plain text.
+func parseLogfmt(s string) (map[string]string, bool) {
+	kv := make(map[string]string)
+	i := 0
+	for i < len(s) {
+		for i < len(s) && s[i] == ' ' {
+			i++
+		}
+		start := i
+		for i < len(s) && s[i] != '=' && s[i] != ' ' {
+			i++
+		}
+		if i >= len(s) || s[i] != '=' || i == start {
+			return nil, false
+		}
+		key := s[start:i]
+		i++
+		var val string
+		if i < len(s) && s[i] == '"' {
+			j := i + 1
+			for j < len(s) && s[j] != '"' {
+				if s[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(s) {
+				return nil, false
+			}
+			unq, err := strconv.Unquote(s[i : j+1])
+			if err != nil {
+				unq = s[i+1 : j]
+			}
+			val, i = unq, j+1
+		} else {
+			start = i
+			for i < len(s) && s[i] != ' ' {
+				i++
+			}
+			val = s[start:i]
+		}
+		kv[key] = val
+	}
+	if len(kv) < 2 {
+		return nil, false
+	}
+	for _, keys := range [][]string{tsKeys, levelKeys, msgKeys} {
+		for _, k := range keys {
+			if _, ok := kv[k]; ok {
+				return kv, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// takeField removes and returns the first of keys present in kv.
+func takeField(kv map[string]string, keys []string) string {
+	for _, k := range keys {
+		if v, ok := kv[k]; ok {
+			delete(kv, k)
+			return v
+		}
+	}
+	return ""
+}
+
+func normalizeLevel(s string) int {
+	// Numeric levels as used by bunyan and pino
+	if n, err := strconv.Atoi(s); err == nil {
+		switch {
+		case n >= 50:
+			return levelError
+		case n >= 40:
+			return levelWarn
+		case n >= 30:
+			return levelInfo
+		case n > 0:
+			return levelDebug
+		}
+		return levelUnknown
+	}
+	switch strings.ToLower(s) {
+	case "fatal", "panic", "critical", "crit", "error", "err", "alert", "emerg":
+		return levelError
+	case "warn", "warning":
+		return levelWarn
+	case "info", "notice", "information":
+		return levelInfo
+	case "debug", "trace":
+		return levelDebug
+	}
+	return levelUnknown
+}
+
+func formatLogTime(s string) string {
+	if s == "" {
+		return ""
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t.Local().Format("15:04:05.000")
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		if f > 1e12 { // Milliseconds
+			f /= 1000
+		}
+		sec := int64(f)
+		return time.Unix(sec, int64((f-float64(sec))*1e9)).Local().Format("15:04:05.000")
+	}
+	return truncate(s, 24)
+}
+
+func levelStyle(level int) lipgloss.Style {
+	switch level {
+	case levelError:
+		return lipgloss.NewStyle().Foreground(cRed)
+	case levelWarn:
+		return lipgloss.NewStyle().Foreground(cOrange)
+	case levelDebug:
+		return lipgloss.NewStyle().Foreground(cDim)
+	}
+	return lipgloss.NewStyle().Foreground(cSecondary)
+}
+
+// renderLogs joins the entries rendered as they arrived, applying the
+// level filter and raw mode.
+func (m model) renderLogs() string {
+	r := m.logRender
+	if r.raw && r.minLevel == levelUnknown {
+		return m.logContent
+	}
+	lines := make([]string, 0, len(m.logEntries))
+	for _, e := range m.logEntries {
+		if r.minLevel != levelUnknown && e.shown < r.minLevel {
+			continue
+		}
+		if r.raw {
+			lines = append(lines, e.tag+e.raw)
+			continue
+		}
+		lines = append(lines, e.rendered)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// rerenderLogs refreshes the cached layout after the field columns change.
+func (m *model) rerenderLogs() {
+	for i := range m.logEntries {
+		m.logEntries[i].rendered = m.renderEntry(m.logEntries[i])
+	}
+}
+
+func (m model) renderEntry(e logEntry) string {
+	style := levelStyle(e.shown)
+	if e.format == "" {
+		return e.tag + style.Render(e.msg)
+	}
+	dim := lipgloss.NewStyle().Foreground(cDim)
+	name := "-"
+	if e.level != levelUnknown {
+		name = strings.ToUpper(levelNames[e.level])
+	}
+	line := dim.Render(fmt.Sprintf("%-12s", e.ts)) + " " + style.Bold(true).Render(fmt.Sprintf("%-5s", name)) + " " + style.Render(e.msg)
+	for _, k := range m.logRender.fields {
+		if v, ok := e.fields[k]; ok {
+			line += " " + dim.Render(k+"=") + v
+		}
+	}
+	return e.tag + line
+}
+
+// structuredKeys lists the extra keys seen across the buffered entries.
+func (m model) structuredKeys() []string {
+	seen := make(map[string]bool)
+	for _, e := range m.logEntries {
+		for k := range e.fields {
+			seen[k] = true
+		}
+	}
+	var keys []string
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (r *logRender) toggleField(key string) {
+	for i, k := range r.fields {
+		if k == key {
+			r.fields = append(r.fields[:i], r.fields[i+1:]...)
+			return
+		}
+	}
+	r.fields = append(r.fields, key)
+}
+
+func (r logRender) hasField(key string) bool {
+	for _, k := range r.fields {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (r logRender) status() string {
+	var parts []string
+	if r.raw {
+		parts = append(parts, "raw")
+	}
+	if r.minLevel != levelUnknown {
+		parts = append(parts, "level≥"+levelNames[r.minLevel])
+	}
+	return strings.Join(parts, " ")
+}
+
+// --- FIELD PICKER ---
+func (m model) logFieldsView() string {
+	var s strings.Builder
+	s.WriteString(headerStyle.Render(" LOG FIELDS ") + "\n\n")
+	if len(m.logRender.pickKeys) == 0 {
+		s.WriteString(lipgloss.NewStyle().Foreground(cDim).Render("No structured fields found.") + "\n")
+	}
+	for i, k := range m.logRender.pickKeys {
+		cursor := "  "
+		style := lipgloss.NewStyle().Foreground(cSecondary)
+		if i == m.logRender.pickCursor {
+			cursor = "> "
+			style = lipgloss.NewStyle().Foreground(cCyan).Bold(true)
+		}
+		check := "[ ] "
+		if m.logRender.hasField(k) {
+			check = "[x] "
+		}
+		s.WriteString(style.Render(cursor+check+k) + "\n")
+	}
+	s.WriteString(footerStyle.Render("\n[Space] Toggle  [Esc] Done"))
+	box := modalStyle.BorderForeground(cCyan).Render(s.String())
+	return strings.Repeat("\n", m.height/4) + lipgloss.PlaceHorizontal(m.width, lipgloss.Center, box)
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// --- LOG STREAMING ---
+const maxLogLines = 5000 // Older lines are dropped from logContent
+
+// logStream follows one container's log, or every container matched by a
+// tail. Lines are handed to Update in batches through the lines channel;
+// a single-container stream closes it when the log ends.
+type logStream struct {
+	id     int
+	cancel context.CancelFunc
+	done   <-chan struct{}
+	lines  chan logLine
+	err    error    // Set before lines is closed
+	tail   *tailSet // Nil unless tailing by selector
+}
+
+// logLine keeps the tail tag apart from the text, so parsing never has to
+// find it in styled output.
+type logLine struct {
+	tag  string // Styled "pod container " prefix of tailed lines
+	text string
+}
+
+type logLinesMsg struct {
+	id    int
+	lines []logLine
+}
+
+type logEndMsg struct {
+	id  int
+	err error
+}
+
+// previous reads the last terminated instance instead; that log is
+// complete, so it is not followed.
+func startLogStream(c *kubernetes.Clientset, p PodInfo, container string, previous bool, id int) *logStream {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &logStream{id: id, cancel: cancel, done: ctx.Done(), lines: make(chan logLine, 256)}
+	go func() {
+		defer close(s.lines)
+		opts := &corev1.PodLogOptions{Container: container, Follow: !previous, Previous: previous, TailLines: func(i int64) *int64 { return &i }(100)}
+		s.err = pumpLogs(ctx, c, p.Namespace, p.Name, opts, "", s.lines)
+	}()
+	return s
+}
+
+// pumpLogs copies a log line by line into out, prefixed with tag. It
+// returns nil when the log ends or ctx is cancelled.
+func pumpLogs(ctx context.Context, c *kubernetes.Clientset, namespace, pod string, opts *corev1.PodLogOptions, tag string, out chan<- logLine) error {
+	stream, err := c.CoreV1().Pods(namespace).GetLogs(pod, opts).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	r := bufio.NewReader(stream)
+	for {
+		line, err := r.ReadString('\n')
+		if line != "" {
+			select {
+			case out <- logLine{tag: tag, text: strings.TrimRight(line, "\r\n")}:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+		if err == io.EOF || ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// waitForLogLines blocks for one line, then drains whatever else is queued
+// so a chatty container costs one viewport refresh per batch.
+func (s *logStream) waitForLogLines() tea.Cmd {
+	return func() tea.Msg {
+		var line logLine
+		var ok bool
+		select {
+		case line, ok = <-s.lines:
+			if !ok {
+				return logEndMsg{id: s.id, err: s.err}
+			}
+		case <-s.done:
+			return nil
+		}
+		lines := []logLine{line}
+		for len(lines) < 1000 {
+			select {
+			case l, ok := <-s.lines:
+				if !ok {
+					return logLinesMsg{id: s.id, lines: lines}
+				}
+				lines = append(lines, l)
+			default:
+				return logLinesMsg{id: s.id, lines: lines}
+			}
+		}
+		return logLinesMsg{id: s.id, lines: lines}
+	}
+}
+
+// startLogs cancels any running stream and follows container in pod.
+func (m *model) startLogs(pod PodInfo, container string, previous bool) tea.Cmd {
+	m.stopLogs()
+	m.logSeq++
+	m.logContainer = container
+	m.logPrevious = previous
+	m.logStream = startLogStream(m.client, pod, container, previous, m.logSeq)
+	m.resetLogBuffer()
+	return m.logStream.waitForLogLines()
+}
+
+func (m *model) resetLogBuffer() {
+	m.logContent = ""
+	m.logLines = nil
+	m.logEntries = nil
+	m.logFollow = true
+	m.viewport.SetContent("")
+}
+
+func (m *model) stopLogs() {
+	if m.logStream != nil {
+		m.logStream.cancel()
+		m.logStream = nil
+	}
+}
+
+func (m *model) appendLogLines(lines []logLine) {
+	for _, l := range lines {
+		m.logLines = append(m.logLines, l.tag+l.text)
+		e := parseLogLine(l)
+		// Lines without a level (stack traces, wrapped output) inherit the
+		// level of the line above, so the level filter keeps them together
+		e.shown = e.level
+		if e.shown == levelUnknown && len(m.logEntries) > 0 {
+			e.shown = m.logEntries[len(m.logEntries)-1].shown
+		}
+		e.rendered = m.renderEntry(e)
+		m.logEntries = append(m.logEntries, e)
+	}
+	if drop := len(m.logLines) - maxLogLines; drop > 0 {
+		m.logLines = m.logLines[drop:]
+		m.logEntries = m.logEntries[drop:]
+	}
+	var b strings.Builder
+	for i, l := range m.logLines {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(l)
+	}
+	m.logContent = b.String()
+	m.refreshViewport()
+}
+
+// logInstance labels which container instance viewLogs is showing.
+func (m model) logInstance() string {
+	if m.logStream != nil && m.logStream.tail != nil {
+		return fmt.Sprintf("%d containers", len(m.logStream.tail.streams))
+	}
+	if !m.logPrevious {
+		return "current instance"
+	}
+	label := "previous instance"
+	if raw, ok := m.watcher.getPod(m.selectedPod.Namespace + "/" + m.selectedPod.Name); ok {
+		if t := lastTermination(raw, m.logContainer); t != "" {
+			label += " · " + t
+		}
+	}
+	return label
+}
+
+// lastTermination describes how container's previous instance ended, or
+// returns "" if it has not restarted.
+func lastTermination(p *corev1.Pod, container string) string {
+	for _, cs := range p.Status.ContainerStatuses {
+		if cs.Name != container || cs.LastTerminationState.Terminated == nil {
+			continue
+		}
+		t := cs.LastTerminationState.Terminated
+		return fmt.Sprintf("exit %d %s, %s ago", t.ExitCode, t.Reason, shortAge(time.Since(t.FinishedAt.Time)))
+	}
+	return ""
+}
+
+func (m model) logStatus() string {
+	switch {
+	case m.logStream == nil:
+		return "[ENDED]"
+	case m.logFollow:
+		return "[FOLLOWING]"
+	default:
+		return "[PAUSED]"
+	}
+}
+
+func logEndText(err error) string {
+	if err != nil {
+		return fmt.Sprintf("--- log stream ended: %v ---", err)
+	}
+	return "--- log stream ended ---"
+}
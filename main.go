@@ -7,9 +7,9 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 	"time"
@@ -20,9 +20,13 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
 	metricsv "k8s.io/metrics/pkg/client/clientset/versioned"
@@ -75,7 +79,9 @@ type PodInfo struct {
 	Message    string
 	Port       int32
 	Age        string
-	Containers []string // List of container names
+	Created    time.Time
+	Containers []string  // List of container names
+	OwnerUID   types.UID // Controlling ReplicaSet, StatefulSet, etc.
 }
 
 type ClusterStats struct {
@@ -94,6 +100,19 @@ const (
 	viewRestartConfirm
 	viewCleanseConfirm
 	viewContainerSelect // New: For multi-container pods
+	viewForwards
+	viewLogFields    // Key picker for structured logs
+	viewApplyConfirm // Diff of an edited object before applying
+	viewWorkloads
+	viewScaleConfirm
+	viewHistory
+	viewRollbackConfirm
+	viewNodes
+	viewDrainConfirm
+	viewDrain
+	viewEvictConfirm
+	viewBulkConfirm
+	viewBulkResult
 )
 
 type sortMode int
@@ -108,13 +127,30 @@ type model struct {
 	client        *kubernetes.Clientset
 	metricsClient *metricsv.Clientset
 	kubeconfig    string
+	restConfig    *rest.Config
+	watcher       *clusterWatcher
+
+	podUsage map[string]corev1.ResourceList // Last metrics-server sample, keyed ns/name
 
 	pods         []PodInfo
 	filteredPods []PodInfo
+	ownerFilter  *WorkloadInfo // Pod list drilled down from the workloads view
+	nodeFilter   string        // Pod list drilled down from the nodes view
 	clusterStats ClusterStats
+	conn         connState
 	namespaces   []string
 	currentNsIdx int
 
+	workloads         []WorkloadInfo
+	filteredWorkloads []WorkloadInfo
+	wlCursor          int
+
+	nodes      []NodeInfo
+	nodeCursor int
+	nodeUsage  map[string]corev1.ResourceList // Last NodeMetrics sample, keyed by name
+	drain      *drainJob
+	drainSeq   int
+
 	state      sessionState
 	sort       sortMode // Current Sort Mode
 	cursor     int
@@ -125,21 +161,54 @@ type model struct {
 	// Input & Viewports
 	textInput    textinput.Model // For Search
 	searchActive bool            // Is search bar open?
-
-	podToDelete *PodInfo
-	viewport    viewport.Model
-	logContent  string
-	diagContent string
-	yamlContent string
+	prompt       textinput.Model // For one-off values (ports, paths...)
+	promptAction string          // What the prompt value is for; "" when closed
+
+	marked       map[string]PodInfo // Multi-select, keyed ns/name
+	bulk         *bulkPlan
+	bulkResults  *bulkDoneMsg
+	podToDelete  *PodInfo
+	deleteOpts   deleteOptions
+	cleanse      *cleanseFilter
+	restart      *restartPlan  // Pending "r" confirmation
+	rollout      *rolloutWatch // Restart or scale being followed in the status bar
+	scale        *scalePlan
+	scaleReturn  sessionState // View the scale action came from
+	history      *rolloutHistory
+	viewport     viewport.Model
+	logContent   string
+	logLines     []string // logContent split by line, trimmed with logEntries
+	logStream    *logStream
+	logSeq       int
+	logFollow    bool // Auto-scroll; paused while scrolled up
+	logContainer string
+	logPrevious  bool       // Showing the last terminated instance
+	logEntries   []logEntry // Parsed logContent, one per line
+	logRender    logRender
+	find         contentSearch
+	diagContent  string
+	yamlContent  string
+	yamlObj      map[string]interface{} // Object behind yamlContent
+	yamlOpts     yamlOptions
+	yamlSeq      int             // Id of the latest fetchYaml
+	yamlFolds    map[string]bool // Folded block paths, e.g. spec.containers[0].env
+	edit         *editSession
 
 	// Container Selection
 	selectedPod     *PodInfo
 	containerList   []string
 	containerCursor int
-	targetAction    string // "logs" or "shell"
+	targetAction    string // "logs", "shell" or "debug"
+	debugTarget     string // Container whose processes the debug container shares
+	debugImage      string
+	copyDraft       *copyJob // Paths entered so far
+	copy            *copyJob // Running transfer
 
 	width, height  int
-	activeForwards map[string]*exec.Cmd
+	activeForwards map[string]*forwarder // Keyed ns/name
+	fwdEvents      chan fwdStatusMsg
+	fwdSeq         int
+	fwdCursor      int
 }
 
 // --- INIT ---
@@ -150,6 +219,7 @@ func main() {
 	} else {
 		kubeconfig = flag.String("kubeconfig", "", "path to kubeconfig")
 	}
+	debugImage := flag.String("debug-image", "busybox:1.36", "image for ephemeral debug containers")
 	flag.Parse()
 	configPath := *kubeconfig
 	if configPath == "" {
@@ -170,33 +240,44 @@ func main() {
 		panic(err)
 	}
 
+	watcher := newClusterWatcher(clientset)
+	watcher.Start()
+
 	ti := textinput.New()
 	ti.Placeholder = "  Enter Pod Name  "
 	ti.CharLimit = 156
 	ti.Width = 30
 
-	p := tea.NewProgram(initialModel(clientset, metricsClient, configPath, ti), tea.WithAltScreen(), tea.WithMouseCellMotion())
+	p := tea.NewProgram(initialModel(clientset, metricsClient, config, watcher, configPath, *debugImage, ti), tea.WithAltScreen(), tea.WithMouseCellMotion())
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error: %v", err)
 		os.Exit(1)
 	}
 }
 
-func initialModel(c *kubernetes.Clientset, m *metricsv.Clientset, k string, ti textinput.Model) model {
+func initialModel(c *kubernetes.Clientset, m *metricsv.Clientset, cfg *rest.Config, w *clusterWatcher, k, img string, ti textinput.Model) model {
 	return model{
 		client:         c,
 		metricsClient:  m,
+		restConfig:     cfg,
+		watcher:        w,
 		kubeconfig:     k,
+		debugImage:     img,
+		podUsage:       make(map[string]corev1.ResourceList),
+		marked:         make(map[string]PodInfo),
 		state:          viewList,
 		loading:        true,
 		namespaces:     []string{"ALL"},
-		activeForwards: make(map[string]*exec.Cmd),
+		activeForwards: make(map[string]*forwarder),
+		fwdEvents:      make(chan fwdStatusMsg, 16),
 		textInput:      ti,
+		prompt:         textinput.New(),
+		yamlOpts:       yamlOptions{status: true},
 	}
 }
 
 func (m model) Init() tea.Cmd {
-	return tea.Batch(fetchPods(m.client, m.metricsClient), fetchClusterStats(m.client, m.metricsClient), fetchNamespaces(m.client), tick())
+	return tea.Batch(m.watcher.waitForPodSync(), m.watcher.waitForPodEvents(), m.watcher.waitForNodes(), m.watcher.waitForNamespaces(), m.watcher.waitForWorkloads(), m.watcher.waitForErrors(), probeAPI(m.client, 0), fetchMetrics(m.metricsClient), waitForForwardStatus(m.fwdEvents), tick())
 }
 
 // --- UPDATE ---
@@ -207,6 +288,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		m.viewport = viewport.New(msg.Width, msg.Height-10)
+		m.refreshViewport() // Keep what was on screen across resizes
 
 	case tea.KeyMsg:
 		// SEARCH BAR HANDLING
@@ -219,6 +301,27 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			default:
 				m.textInput, cmd = m.textInput.Update(msg)
 				m.filterPods() // Live Filter
+				m.filterWorkloads()
+				return m, cmd
+			}
+		}
+
+		// PROMPT HANDLING
+		if m.promptAction != "" {
+			switch msg.String() {
+			case "enter":
+				action, value := m.promptAction, m.prompt.Value()
+				m.closePrompt()
+				return m.submitPrompt(action, value)
+			case "esc":
+				if m.promptAction == "scale" {
+					m.scale = nil
+				}
+				m.closePrompt()
+				m.msg = "Cancelled"
+				return m, nil
+			default:
+				m.prompt, cmd = m.prompt.Update(msg)
 				return m, cmd
 			}
 		}
@@ -227,12 +330,29 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case viewList:
 			switch msg.String() {
 			case "q", "ctrl+c":
-				for _, cmd := range m.activeForwards {
-					if cmd.Process != nil {
-						cmd.Process.Kill()
-					}
+				return m.quit()
+			case "esc":
+				if len(m.marked) > 0 {
+					m.marked = make(map[string]PodInfo)
+					m.msg = "Marks cleared"
+				} else if m.ownerFilter != nil {
+					m.ownerFilter = nil
+					m.cursor = 0
+					m.filterPods()
+					m.state = viewWorkloads
+				} else if m.nodeFilter != "" {
+					m.nodeFilter = ""
+					m.cursor = 0
+					m.filterPods()
+					m.state = viewNodes
 				}
-				return m, tea.Quit
+			case "N":
+				m.loadNodes()
+				m.state = viewNodes
+				m.msg = fmt.Sprintf("%d nodes", len(m.nodes))
+			case "W":
+				m.state = viewWorkloads
+				m.msg = fmt.Sprintf("%d workloads", len(m.filteredWorkloads))
 			case "up", "k":
 				if m.cursor > 0 {
 					m.cursor--
@@ -242,6 +362,20 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.cursor++
 				}
 
+			// --- MULTI-SELECT ---
+			case " ":
+				m.toggleMark()
+			case "a":
+				start, end := m.calculatePagination(m.cursor, len(m.filteredPods))
+				m.markPods(m.filteredPods[start:end])
+			case "A":
+				m.markPods(m.filteredPods)
+			case "l":
+				if len(m.filteredPods) > 0 {
+					m.openPrompt("label", "labels (key=value, key- removes)", "")
+					return m, textinput.Blink
+				}
+
 			// --- SEARCH ---
 			case "/":
 				m.searchActive = true
@@ -259,15 +393,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.msg = "Sort: Memory Usage"
 
 			case "n":
-				if len(m.namespaces) > 1 {
-					m.currentNsIdx++
-					if m.currentNsIdx >= len(m.namespaces) {
-						m.currentNsIdx = 0
-					}
-					m.cursor = 0
-					m.sort = sortDefault
-					m.filterPods() // Reset sort on NS change
-				}
+				m.cycleNamespace()
 			case "tab":
 				m.showIssues = !m.showIssues
 				m.cursor = 0
@@ -287,6 +413,18 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if len(m.filteredPods) > 0 {
 					return m.initiateAction(m.filteredPods[m.cursor], "shell")
 				}
+			case "b":
+				if len(m.filteredPods) > 0 {
+					return m.initiateAction(m.filteredPods[m.cursor], "debug")
+				}
+			case "g", "u":
+				if len(m.filteredPods) > 0 {
+					action := "copyfrom"
+					if msg.String() == "u" {
+						action = "copyto"
+					}
+					return m.initiateAction(m.filteredPods[m.cursor], action)
+				}
 
 			case "?":
 				if len(m.filteredPods) > 0 {
@@ -294,43 +432,99 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.selectedPod = &selected
 					m.state = viewDiagnosis
 					m.msg = fmt.Sprintf("Diagnosing %s...", selected.Name)
-					return m, diagnosePod(m.client, selected)
+					raw, _ := m.watcher.getPod(selected.Namespace + "/" + selected.Name)
+					return m, diagnosePod(m.client, selected, raw)
+				}
+			case "T":
+				if len(m.marked) > 0 {
+					return m, m.tailMarked()
+				}
+				if len(m.filteredPods) > 0 {
+					selected := m.filteredPods[m.cursor]
+					raw, _ := m.watcher.getPod(selected.Namespace + "/" + selected.Name)
+					m.msg = fmt.Sprintf("Resolving owner of %s...", selected.Name)
+					return m, resolveTailTarget(m.client, selected, raw)
 				}
+			case "L":
+				m.openPrompt("tail", "label selector", "")
+				return m, textinput.Blink
 			case "y":
 				if len(m.filteredPods) > 0 {
 					selected := m.filteredPods[m.cursor]
 					m.selectedPod = &selected
 					m.state = viewYaml
 					m.msg = fmt.Sprintf("Fetching YAML...")
-					return m, fetchYaml(selected.Namespace, selected.Name)
+					m.yamlObj = nil
+					m.yamlContent = ""
+					m.yamlFolds = make(map[string]bool)
+					m.yamlSeq++
+					return m, fetchYaml(m.client, selected.Namespace, selected.Name, m.yamlSeq)
 				}
 			case "r":
+				if len(m.marked) > 0 {
+					m.openBulk("restart")
+				} else if len(m.filteredPods) > 0 {
+					selected := m.filteredPods[m.cursor]
+					raw, _ := m.watcher.getPod(selected.Namespace + "/" + selected.Name)
+					m.msg = fmt.Sprintf("Resolving owner of %s...", selected.Name)
+					return m, resolveRestart(m.client, selected, raw)
+				}
+			case "S":
 				if len(m.filteredPods) > 0 {
 					selected := m.filteredPods[m.cursor]
-					m.podToDelete = &selected
-					m.state = viewRestartConfirm
+					raw, ok := m.watcher.getPod(selected.Namespace + "/" + selected.Name)
+					if !ok {
+						m.msg = fmt.Sprintf("%s is gone", selected.Name)
+						return m, nil
+					}
+					m.msg = fmt.Sprintf("Resolving owner of %s...", selected.Name)
+					m.scaleReturn = m.state
+					return m, resolveScaleTarget(m.client, raw, workloadRef{})
 				}
-			case "d":
+			case "H":
 				if len(m.filteredPods) > 0 {
+					selected := m.filteredPods[m.cursor]
+					raw, ok := m.watcher.getPod(selected.Namespace + "/" + selected.Name)
+					if !ok {
+						m.msg = fmt.Sprintf("%s is gone", selected.Name)
+						return m, nil
+					}
+					m.msg = fmt.Sprintf("Resolving owner of %s...", selected.Name)
+					return m, resolveHistoryTarget(m.client, raw)
+				}
+			case "d":
+				if len(m.marked) > 0 {
+					m.openBulk("delete")
+				} else if len(m.filteredPods) > 0 {
 					selected := m.filteredPods[m.cursor]
 					m.podToDelete = &selected
+					m.deleteOpts = deleteOptions{grace: -1}
 					m.state = viewDeleteConfirm
 				}
+			case "e":
+				if len(m.marked) > 0 {
+					m.openBulk("evict")
+				} else if len(m.filteredPods) > 0 {
+					selected := m.filteredPods[m.cursor]
+					m.podToDelete = &selected
+					m.state = viewEvictConfirm
+				}
 			case "C":
 				currentNs := m.namespaces[m.currentNsIdx]
 				if currentNs == "ALL" {
 					m.msg = "⚠️ Cannot Cleanse 'ALL'. Select a namespace."
 				} else {
+					if m.cleanse == nil {
+						m.cleanse = newCleanseFilter() // Kept between uses
+					}
 					m.state = viewCleanseConfirm
 				}
 			case "f":
 				if len(m.filteredPods) > 0 {
 					selected := m.filteredPods[m.cursor]
 					key := selected.Namespace + "/" + selected.Name
-					if cmd, exists := m.activeForwards[key]; exists {
-						if cmd.Process != nil {
-							cmd.Process.Kill()
-						}
+					if f, exists := m.activeForwards[key]; exists {
+						f.Stop()
 						delete(m.activeForwards, key)
 						m.msg = fmt.Sprintf("Stopped forwarding %s", selected.Name)
 					} else {
@@ -338,15 +532,35 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						if targetPort == 0 {
 							targetPort = 80
 						}
-						c := exec.Command("kubectl", "port-forward", "-n", selected.Namespace, selected.Name, fmt.Sprintf("8080:%d", targetPort))
-						if err := c.Start(); err == nil {
-							m.activeForwards[key] = c
-							m.msg = fmt.Sprintf("Forwarding %s -> :8080", selected.Name)
-						} else {
-							m.msg = fmt.Sprintf("Forward fail: %v", err)
-						}
+						m.fwdSeq++
+						m.activeForwards[key] = startForward(m.restConfig, m.client, key, m.fwdSeq, selected, 0, targetPort, nil, m.fwdEvents)
+						m.msg = fmt.Sprintf("Forwarding %s :%d...", selected.Name, targetPort)
 					}
 				}
+			case "F":
+				m.state = viewForwards
+				m.fwdCursor = 0
+				m.msg = fmt.Sprintf("%d active forwards", len(m.activeForwards))
+				return m, fwdTick()
+			}
+
+		case viewForwards:
+			return m.updateForwards(msg)
+		case viewWorkloads:
+			return m.updateWorkloads(msg)
+		case viewHistory:
+			return m.updateHistory(msg)
+		case viewNodes:
+			return m.updateNodes(msg)
+		case viewRollbackConfirm:
+			return m.updateRollbackConfirm(msg)
+		case viewBulkConfirm:
+			return m.updateBulkConfirm(msg)
+		case viewBulkResult:
+			switch msg.String() {
+			case "esc", "q", "enter":
+				m.bulkResults = nil
+				m.state = viewList
 			}
 
 		// --- CONTAINER SELECTOR ---
@@ -368,18 +582,68 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.state = viewList // Reset state before executing
 				if m.targetAction == "logs" {
 					m.state = viewLogs
-					return m, fetchLogs(m.client, *m.selectedPod, container)
+					return m, m.startLogs(*m.selectedPod, container, false)
 				} else if m.targetAction == "shell" {
-					return m, openShell(m.selectedPod.Namespace, m.selectedPod.Name, container, m.kubeconfig)
+					return m, openShell(m.restConfig, m.client, m.selectedPod.Namespace, m.selectedPod.Name, container)
+				} else if m.targetAction == "debug" {
+					m.promptDebug(*m.selectedPod, container)
+					return m, textinput.Blink
+				} else if m.targetAction == "copyfrom" || m.targetAction == "copyto" {
+					m.promptCopy(*m.selectedPod, container, m.targetAction == "copyto")
+					return m, textinput.Blink
 				}
 			}
 
+		case viewDrainConfirm:
+			switch msg.String() {
+			case "y", "Y":
+				m.state = viewDrain
+				m.msg = fmt.Sprintf("Cordoning %s...", m.drain.node)
+				return m, m.startDrain()
+			case "n", "N", "esc", "q":
+				m.drain = nil
+				m.state = viewNodes
+				m.msg = "Drain cancelled."
+			}
+		case viewDrain:
+			return m.updateDrain(msg)
 		case viewCleanseConfirm:
 			switch msg.String() {
 			case "y", "Y":
-				cmd = cleanseNamespace(m.client, m.namespaces[m.currentNsIdx])
+				pods, _ := m.cleansePreview()
+				if len(pods) == 0 {
+					m.msg = "Nothing to cleanse."
+					return m, nil
+				}
+				ns := m.namespaces[m.currentNsIdx]
+				m.msg = fmt.Sprintf("Cleansing %d pods in %s...", len(pods), ns)
 				m.state = viewList
-				return m, cmd
+				return m, cleanseNamespace(m.client, ns, pods)
+			case "up", "k":
+				if m.cleanse.cursor > 0 {
+					m.cleanse.cursor--
+				}
+			case "down", "j":
+				if m.cleanse.cursor < len(m.cleanse.items())-1 {
+					m.cleanse.cursor++
+				}
+			case " ", "enter":
+				item := m.cleanse.items()[m.cleanse.cursor]
+				m.cleanse.checked[item] = !m.cleanse.checked[item]
+			case "a":
+				age := ""
+				if m.cleanse.minAge > 0 {
+					age = shortAge(m.cleanse.minAge)
+				}
+				m.openPrompt("cleanse-age", "older than (30m, 6h, 2d)", age)
+				return m, textinput.Blink
+			case "l":
+				sel := ""
+				if m.cleanse.selector != nil {
+					sel = m.cleanse.selector.String()
+				}
+				m.openPrompt("cleanse-selector", "label selector", sel)
+				return m, textinput.Blink
 			case "n", "N", "esc", "q":
 				m.state = viewList
 				m.msg = "Cleanse cancelled."
@@ -387,32 +651,203 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case viewRestartConfirm:
 			switch msg.String() {
 			case "y", "Y":
-				m.msg = fmt.Sprintf("Restarting %s...", m.podToDelete.Name)
-				cmd = deletePod(m.client, *m.podToDelete)
-				m.podToDelete = nil
+				p := m.restart
+				switch {
+				case p.rollout:
+					m.msg = fmt.Sprintf("Restarting %s...", p.owner)
+					cmd = rolloutRestart(m.client, p.owner)
+				case p.owner.Kind != "":
+					m.msg = fmt.Sprintf("Restarting %s...", p.pod.Name)
+					cmd = deletePod(m.client, p.pod, metav1.DeleteOptions{})
+				default:
+					return m, nil
+				}
+				m.restart = nil
 				m.state = viewList
 				return m, cmd
 			case "n", "N", "esc", "q":
-				m.podToDelete = nil
+				m.restart = nil
 				m.state = viewList
 				m.msg = "Restart cancelled."
 			}
+		case viewScaleConfirm:
+			switch msg.String() {
+			case "y", "Y":
+				m.msg = fmt.Sprintf("Scaling %s to %d...", m.scale.owner, m.scale.replicas)
+				cmd = applyScale(m.client, *m.scale)
+				m.scale = nil
+				m.state = m.scaleReturn
+				return m, cmd
+			case "n", "N", "esc", "q":
+				m.scale = nil
+				m.state = m.scaleReturn
+				m.msg = "Scale cancelled."
+			}
 		case viewDeleteConfirm:
 			switch msg.String() {
 			case "y", "Y":
 				m.msg = fmt.Sprintf("Deleting %s...", m.podToDelete.Name)
-				cmd = deletePod(m.client, *m.podToDelete)
+				cmd = deletePod(m.client, *m.podToDelete, m.deleteOpts.apiOptions())
 				m.podToDelete = nil
 				m.state = viewList
 				return m, cmd
+			case "g":
+				grace := ""
+				if m.deleteOpts.grace >= 0 {
+					grace = strconv.FormatInt(m.deleteOpts.grace, 10)
+				}
+				m.openPrompt("grace", "grace period seconds (empty for default)", grace)
+				return m, textinput.Blink
+			case "f":
+				m.deleteOpts.force = !m.deleteOpts.force
 			case "n", "N", "esc", "q":
 				m.podToDelete = nil
 				m.state = viewList
 				m.msg = "Delete cancelled."
 			}
-		case viewLogs, viewDiagnosis, viewYaml:
+		case viewEvictConfirm:
+			switch msg.String() {
+			case "y", "Y":
+				p := *m.podToDelete
+				raw, _ := m.watcher.getPod(p.Namespace + "/" + p.Name)
+				m.msg = fmt.Sprintf("Evicting %s...", p.Name)
+				m.podToDelete = nil
+				m.state = viewList
+				return m, evictPodCmd(m.client, p, raw)
+			case "n", "N", "esc", "q":
+				m.podToDelete = nil
+				m.state = viewList
+				m.msg = "Evict cancelled."
+			}
+		case viewLogs:
+			if ok, cmd := m.viewerKey(msg.String()); ok {
+				return m, cmd
+			}
 			switch msg.String() {
 			case "esc", "q":
+				m.stopLogs()
+				m.find = contentSearch{}
+				m.state = viewList
+				m.msg = "Dashboard"
+			case "G", "end":
+				m.viewport.GotoBottom()
+				m.logFollow = true
+			case "p":
+				if m.logStream != nil && m.logStream.tail != nil {
+					m.msg = "Previous logs are not available while tailing"
+					return m, nil
+				}
+				cmd = m.startLogs(*m.selectedPod, m.logContainer, !m.logPrevious)
+				m.msg = fmt.Sprintf("Logs: %s (%s)", m.selectedPod.Name, m.logInstance())
+				return m, cmd
+			case "J":
+				m.logRender.raw = !m.logRender.raw
+				m.refreshViewport()
+			case "v":
+				m.logRender.minLevel = (m.logRender.minLevel + 1) % len(levelNames)
+				m.msg = "Level filter: " + levelNames[m.logRender.minLevel]
+				m.refreshViewport()
+			case "K":
+				m.logRender.pickKeys = m.structuredKeys()
+				m.logRender.pickCursor = 0
+				m.state = viewLogFields
+			default:
+				m.viewport, cmd = m.viewport.Update(msg)
+				m.logFollow = m.viewport.AtBottom()
+				return m, cmd
+			}
+		case viewLogFields:
+			r := &m.logRender
+			switch msg.String() {
+			case "esc", "q", "K":
+				m.state = viewLogs
+				m.rerenderLogs()
+				m.refreshViewport()
+			case "up", "k":
+				if r.pickCursor > 0 {
+					r.pickCursor--
+				}
+			case "down", "j":
+				if r.pickCursor < len(r.pickKeys)-1 {
+					r.pickCursor++
+				}
+			case " ", "enter":
+				if len(r.pickKeys) > 0 {
+					r.toggleField(r.pickKeys[r.pickCursor])
+				}
+			}
+		case viewYaml:
+			if ok, cmd := m.viewerKey(msg.String()); ok {
+				return m, cmd
+			}
+			switch msg.String() {
+			case "esc", "q":
+				m.find = contentSearch{}
+				m.state = viewList
+				m.msg = "Dashboard"
+			case "M":
+				m.yamlOpts.managedFields = !m.yamlOpts.managedFields
+				m.renderYaml()
+			case "S":
+				m.yamlOpts.status = !m.yamlOpts.status
+				m.renderYaml()
+			case "o":
+				m.yamlOpts.json = !m.yamlOpts.json
+				m.renderYaml()
+			case "z":
+				if !m.yamlOpts.json {
+					m.openPrompt("fold", "fold path", "")
+					return m, textinput.Blink
+				}
+			case "Z":
+				m.yamlFolds = make(map[string]bool)
+				m.refreshViewport()
+			case "e":
+				if m.yamlObj == nil {
+					m.msg = "Nothing to edit"
+					return m, nil
+				}
+				return m, m.startEdit()
+			default:
+				m.viewport, cmd = m.viewport.Update(msg)
+				return m, cmd
+			}
+		case viewApplyConfirm:
+			switch msg.String() {
+			case "y", "Y":
+				if m.edit.edited == nil {
+					m.msg = "Fix the errors first: [e] Edit again"
+					return m, nil
+				}
+				if m.edit.conflict {
+					m.msg = "Object changed on server: [u] Overwrite or [e] Edit again"
+					return m, nil
+				}
+				m.msg = fmt.Sprintf("Applying %s...", m.edit.name)
+				return m, applyEdit(m.client, *m.edit, false)
+			case "u":
+				if m.edit.conflict && m.edit.edited != nil {
+					m.msg = fmt.Sprintf("Overwriting %s...", m.edit.name)
+					return m, applyEdit(m.client, *m.edit, true)
+				}
+			case "e":
+				return m, openEditor(m.edit, m.edit.text)
+			case "n", "N", "esc", "q":
+				m.endEdit()
+				m.state = viewYaml
+				m.refreshViewport()
+				m.msg = "Edit discarded."
+			default:
+				m.viewport, cmd = m.viewport.Update(msg)
+				return m, cmd
+			}
+		case viewDiagnosis:
+			if ok, cmd := m.viewerKey(msg.String()); ok {
+				return m, cmd
+			}
+			switch msg.String() {
+			case "esc", "q":
+				m.find = contentSearch{}
 				m.state = viewList
 				m.msg = "Dashboard"
 			default:
@@ -422,41 +857,277 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case tickMsg:
-		return m, tea.Batch(fetchPods(m.client, m.metricsClient), fetchClusterStats(m.client, m.metricsClient), tick())
-	case podsMsg:
-		m.pods = msg
+		return m, tea.Batch(fetchMetrics(m.metricsClient), tick())
+	case podsSyncedMsg:
+		m.pods = nil
+		for _, p := range m.watcher.listPods() {
+			m.pods = append(m.pods, podInfoFromPod(p, m.podUsage))
+		}
 		m.loading = false
+		m.pruneMarks()
 		m.filterPods()
-		if m.cursor >= len(m.filteredPods) {
-			if len(m.filteredPods) > 0 {
-				m.cursor = len(m.filteredPods) - 1
-			} else {
-				m.cursor = 0
+		m.clampCursor()
+		if m.logStream != nil && m.logStream.tail != nil {
+			m.syncTail()
+		}
+	case podEventsMsg:
+		m.applyPodEvents(msg)
+		m.pruneMarks()
+		if m.state == viewNodes {
+			m.loadNodes() // Pod counts
+		}
+		if !m.loading {
+			m.filterPods()
+			m.clampCursor()
+		}
+		if m.logStream != nil && m.logStream.tail != nil {
+			m.syncTail()
+		}
+		return m, m.watcher.waitForPodEvents()
+	case tailTargetMsg:
+		if msg.err != nil {
+			m.msg = fmt.Sprintf("Tail failed: %v (use [L] to enter a selector)", msg.err)
+			return m, nil
+		}
+		m.selectedPod = &msg.pod
+		m.state = viewLogs
+		m.msg = fmt.Sprintf("Tailing %s", msg.ref)
+		return m, m.startTail(msg.ref.Namespace, msg.ref.String(), msg.ref.Selector, nil)
+	case nodesChangedMsg:
+		m.clusterStats.TotalCpuCap, m.clusterStats.TotalMemCap = 0, 0
+		nodes := m.watcher.listNodes()
+		for _, n := range nodes {
+			m.clusterStats.TotalCpuCap += n.Status.Allocatable.Cpu().MilliValue()
+			m.clusterStats.TotalMemCap += n.Status.Allocatable.Memory().Value()
+		}
+		m.clusterStats.NodeCount = len(nodes)
+		m.loadNodes()
+		return m, m.watcher.waitForNodes()
+	case nsChangedMsg:
+		current := m.namespaces[m.currentNsIdx]
+		ns := m.watcher.listNamespaces()
+		sort.Strings(ns)
+		m.namespaces = append([]string{"ALL"}, ns...)
+		m.currentNsIdx = 0
+		for i, n := range m.namespaces {
+			if n == current {
+				m.currentNsIdx = i
 			}
 		}
-	case statsMsg:
-		m.clusterStats = ClusterStats(msg)
-	case nsMsg:
-		m.namespaces = append([]string{"ALL"}, msg...)
-	case logsMsg:
-		m.logContent = string(msg)
-		m.viewport.SetContent(m.logContent)
-		m.viewport.GotoBottom()
+		m.filterPods()
+		m.filterWorkloads()
+		return m, m.watcher.waitForNamespaces()
+	case workloadsChangedMsg:
+		m.loadWorkloads()
+		m.filterWorkloads()
+		m.trackRollout()
+		if m.history != nil {
+			m.loadHistory()
+		}
+		if m.ownerFilter != nil {
+			m.filterPods() // A rollout brings a new ReplicaSet
+		}
+		return m, m.watcher.waitForWorkloads()
+	case metricsMsg:
+		m.podUsage = msg.pods
+		if msg.nodesOk {
+			m.clusterStats.TotalCpuUsage = msg.nodeCpu
+			m.clusterStats.TotalMemUsage = msg.nodeMem
+			m.nodeUsage = msg.nodes
+		}
+		m.loadNodes()
+		for i := range m.pods {
+			m.pods[i].applyUsage(m.podUsage)
+		}
+		m.filterPods()
+	case logLinesMsg:
+		if m.logStream == nil || msg.id != m.logStream.id {
+			return m, nil // Cancelled stream
+		}
+		m.appendLogLines(msg.lines)
+		return m, m.logStream.waitForLogLines()
+	case logEndMsg:
+		if m.logStream == nil || msg.id != m.logStream.id {
+			return m, nil
+		}
+		m.logStream = nil
+		m.appendLogLines([]logLine{{text: logEndText(msg.err)}})
 	case diagMsg:
 		m.diagContent = string(msg)
-		m.viewport.SetContent(m.diagContent)
+		m.refreshViewport()
 		m.viewport.GotoTop()
 	case yamlMsg:
-		m.yamlContent = string(msg)
-		m.viewport.SetContent(m.yamlContent)
+		if msg.id != m.yamlSeq {
+			return m, nil // Superseded by a later fetch
+		}
+		if msg.err != nil {
+			m.yamlObj = nil
+			m.yamlContent = "Error: " + msg.err.Error()
+			m.refreshViewport()
+			m.msg = "Fetch failed"
+			return m, nil
+		}
+		m.yamlObj = msg.obj
+		m.renderYaml()
 		m.viewport.GotoTop()
+		m.msg = ""
+	case fwdStatusMsg:
+		// Ignore reports from forwards that were stopped or replaced
+		if f, ok := m.activeForwards[msg.key]; ok && f.id == msg.id {
+			f.State = msg.state
+			f.Err = msg.err
+			switch msg.state {
+			case fwdReady:
+				f.LocalPort = msg.localPort
+				m.msg = fmt.Sprintf("Forwarding %s -> localhost:%d", f.Pod, f.LocalPort)
+			case fwdError:
+				f.Stop()
+				m.msg = fmt.Sprintf("Forward %s failed: %v", f.Pod, msg.err)
+			}
+		}
+		return m, waitForForwardStatus(m.fwdEvents)
+	case fwdTickMsg:
+		if m.state == viewForwards {
+			return m, fwdTick()
+		}
+	case restartPlanMsg:
+		if msg.err != nil {
+			m.msg = fmt.Sprintf("Restart failed: %v", msg.err)
+			return m, nil
+		}
+		m.restart = &msg.plan
+		m.state = viewRestartConfirm
+	case historyTargetMsg:
+		if msg.err != nil {
+			m.msg = fmt.Sprintf("History failed: %v", msg.err)
+			return m, nil
+		}
+		m.openHistory(msg.owner.Namespace, msg.owner.Name)
+	case scaleTargetMsg:
+		return m, m.openScalePrompt(msg)
+	case rolloutStartedMsg:
+		if msg.err != nil {
+			m.msg = fmt.Sprintf("%s failed: %v", msg.watch.verb, msg.err)
+			return m, nil
+		}
+		m.rollout = &msg.watch
+		m.trackRollout()
+	case drainEventMsg:
+		return m, m.applyDrainEvent(msg)
+	case evictMsg:
+		switch {
+		case msg.err == nil:
+			m.msg = fmt.Sprintf("Evicted %s.", msg.pod)
+		case msg.pdb != "":
+			m.msg = fmt.Sprintf("Eviction of %s blocked by PodDisruptionBudget %s", msg.pod, msg.pdb)
+		default:
+			m.msg = fmt.Sprintf("Evict failed: %v", msg.err)
+		}
+	case nodeMsg:
+		m.msg = string(msg)
 	case deleteMsg:
 		m.msg = string(msg)
-		return m, fetchPods(m.client, m.metricsClient)
+	case apiErrMsg:
+		m.conn.failed(msg.err, msg.action == "")
+		if msg.action == "" {
+			return m, m.watcher.waitForErrors()
+		}
+		m.msg = fmt.Sprintf("%s failed: %v", msg.action, msg.err)
+	case probeMsg:
+		return m, m.conn.probed(m.client, msg.err)
+	case bulkDoneMsg:
+		m.finishBulk(msg)
+	case saveMsg:
+		m.msg = string(msg)
+	case shellReadyMsg:
+		m.msg = fmt.Sprintf("%s: %s", msg.what, msg.pod)
+		return m, tea.Exec(msg.session, func(err error) tea.Msg { return execDoneMsg{what: msg.what, err: err} })
+	case copyTickMsg:
+		if m.copy != nil {
+			m.msg = m.copy.progress()
+			return m, copyTick()
+		}
+	case copyDoneMsg:
+		m.copy = nil
+		if msg.err != nil {
+			m.msg = fmt.Sprintf("Copy failed: %v", msg.err)
+		} else {
+			m.msg = fmt.Sprintf("Copied %d files (%s) %s in %s", msg.files, humanBytes(msg.job.bytes.Load()), msg.job.describe(), time.Since(msg.job.started).Round(100*time.Millisecond))
+		}
+	case execDoneMsg:
+		if msg.err != nil {
+			m.msg = fmt.Sprintf("%s failed: %v", msg.what, msg.err)
+		} else {
+			m.msg = fmt.Sprintf("%s session ended.", msg.what)
+		}
+	case editDoneMsg:
+		if m.edit == nil {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.endEdit()
+			m.state = viewYaml
+			m.refreshViewport()
+			m.msg = fmt.Sprintf("Editor failed: %v", msg.err)
+			return m, nil
+		}
+		changed, err := m.edit.readEdit()
+		if !changed && err == nil {
+			m.endEdit()
+			m.state = viewYaml
+			m.refreshViewport()
+			m.msg = "Edit cancelled, no changes."
+			return m, nil
+		}
+		m.edit.err, m.edit.conflict, m.edit.diff = err, false, ""
+		if err != nil {
+			m.state = viewApplyConfirm
+			m.refreshViewport()
+			m.viewport.GotoTop()
+			return m, nil
+		}
+		m.msg = "Computing diff..."
+		return m, fetchEditDiff(m.client, *m.edit)
+	case editDiffMsg:
+		if m.edit == nil {
+			return m, nil
+		}
+		m.edit.diff, m.edit.err = msg.diff, msg.err
+		m.state = viewApplyConfirm
+		m.refreshViewport()
+		m.viewport.GotoTop()
+		m.msg = ""
+	case editApplyMsg:
+		if m.edit == nil {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.edit.err = msg.err
+			m.edit.conflict = apierrors.IsConflict(msg.err)
+			m.refreshViewport()
+			m.viewport.GotoTop()
+			m.msg = "Apply failed."
+			return m, nil
+		}
+		ns, name := m.edit.namespace, m.edit.name
+		m.endEdit()
+		m.state = viewYaml
+		m.msg = fmt.Sprintf("Applied changes to %s.", name)
+		m.yamlSeq++
+		return m, fetchYaml(m.client, ns, name, m.yamlSeq)
 	}
 	return m, nil
 }
 
+func (m model) quit() (tea.Model, tea.Cmd) {
+	for _, f := range m.activeForwards {
+		f.Stop()
+	}
+	m.stopLogs()
+	m.watcher.Stop()
+	return m, tea.Quit
+}
+
 // --- MULTI-CONTAINER LOGIC ---
 func (m *model) initiateAction(pod PodInfo, action string) (tea.Model, tea.Cmd) {
 	if len(pod.Containers) > 1 {
@@ -477,9 +1148,130 @@ func (m *model) initiateAction(pod PodInfo, action string) (tea.Model, tea.Cmd)
 		m.selectedPod = &pod
 		m.state = viewLogs
 		m.msg = fmt.Sprintf("Logs: %s", pod.Name)
-		return m, fetchLogs(m.client, pod, container)
+		return m, m.startLogs(pod, container, false)
+	} else if action == "debug" {
+		m.promptDebug(pod, container)
+		return m, textinput.Blink
+	} else if action == "copyfrom" || action == "copyto" {
+		m.promptCopy(pod, container, action == "copyto")
+		return m, textinput.Blink
 	} else {
-		return m, openShell(pod.Namespace, pod.Name, container, m.kubeconfig)
+		return m, openShell(m.restConfig, m.client, pod.Namespace, pod.Name, container)
+	}
+}
+
+// --- PROMPT ---
+func (m *model) openPrompt(action, placeholder, value string) {
+	m.promptAction = action
+	m.prompt.Placeholder = placeholder
+	m.prompt.SetValue(value)
+	m.prompt.CursorEnd()
+	m.prompt.Focus()
+}
+
+func (m model) promptView() string {
+	return searchStyle.Render(strings.ToUpper(m.prompt.Placeholder) + ": " + m.prompt.View())
+}
+
+func (m *model) closePrompt() {
+	m.promptAction = ""
+	m.prompt.Blur()
+}
+
+func (m model) submitPrompt(action, value string) (tea.Model, tea.Cmd) {
+	switch action {
+	case "retarget":
+		m.retargetForward(value)
+	case "find":
+		m.setSearch(value)
+	case "fold":
+		m.toggleFold(value)
+	case "save":
+		return m, saveBuffer(value, m.saveContent())
+	case "cleanse-age":
+		m.submitCleanseAge(value)
+	case "cleanse-selector":
+		m.submitCleanseSelector(value)
+	case "grace":
+		m.submitGrace(value)
+	case "scale":
+		m.submitScale(value)
+	case "cp-local", "cp-remote":
+		return m, m.submitCopyPath(action, value)
+	case "debug":
+		if strings.TrimSpace(value) == "" || m.selectedPod == nil {
+			return m, nil
+		}
+		m.msg = fmt.Sprintf("Starting debug container in %s...", m.selectedPod.Name)
+		return m, startDebug(m.restConfig, m.client, m.selectedPod.Namespace, m.selectedPod.Name, m.debugTarget, strings.TrimSpace(value))
+	case "label":
+		m.submitLabels(value)
+	case "tail":
+		sel, err := labels.Parse(value)
+		if err != nil || sel.Empty() {
+			m.msg = fmt.Sprintf("Invalid selector %q", value)
+			return m, nil
+		}
+		ns := m.namespaces[m.currentNsIdx]
+		if ns == "ALL" {
+			ns = ""
+		}
+		m.selectedPod = nil
+		m.state = viewLogs
+		m.msg = fmt.Sprintf("Tailing %s", sel)
+		return m, m.startTail(ns, sel.String(), sel, nil)
+	}
+	return m, nil
+}
+
+func (m model) podExists(key string) bool {
+	_, ok := m.watcher.getPod(key)
+	return ok
+}
+
+// --- INFORMER UPDATES ---
+// applyPodEvents re-reads each changed pod from the informer cache, so the
+// order in which notifications arrive does not matter.
+func (m *model) applyPodEvents(keys []string) {
+	index := make(map[string]int, len(m.pods))
+	for i, p := range m.pods {
+		index[p.Namespace+"/"+p.Name] = i
+	}
+	removed := make(map[string]bool)
+	for _, key := range keys {
+		pod, exists := m.watcher.getPod(key)
+		i, known := index[key]
+		switch {
+		case exists && known:
+			m.pods[i] = podInfoFromPod(pod, m.podUsage)
+			delete(removed, key)
+		case exists:
+			index[key] = len(m.pods)
+			m.pods = append(m.pods, podInfoFromPod(pod, m.podUsage))
+			delete(removed, key)
+		case known:
+			removed[key] = true
+		}
+	}
+	if len(removed) == 0 {
+		return
+	}
+	kept := m.pods[:0]
+	for _, p := range m.pods {
+		if !removed[p.Namespace+"/"+p.Name] {
+			kept = append(kept, p)
+		}
+	}
+	m.pods = kept
+}
+
+func (m *model) clampCursor() {
+	if m.cursor >= len(m.filteredPods) {
+		if len(m.filteredPods) > 0 {
+			m.cursor = len(m.filteredPods) - 1
+		} else {
+			m.cursor = 0
+		}
 	}
 }
 
@@ -488,12 +1280,24 @@ func (m *model) filterPods() {
 	var target []PodInfo
 	selectedNs := m.namespaces[m.currentNsIdx]
 	searchTerm := strings.ToLower(m.textInput.Value())
+	var owners map[types.UID]bool
+	if o := m.ownerFilter; o != nil {
+		owners = m.watcher.ownerUIDs(o.Kind, o.Namespace, o.UID)
+	}
 
 	for _, p := range m.pods {
 		// Namespace Filter
 		if selectedNs != "ALL" && p.Namespace != selectedNs {
 			continue
 		}
+		// Owner Filter
+		if owners != nil && !owners[p.OwnerUID] {
+			continue
+		}
+		// Node Filter
+		if m.nodeFilter != "" && p.NodeName != m.nodeFilter {
+			continue
+		}
 		// Status Filter
 		if m.showIssues {
 			if (p.Status == "Running" || p.Status == "Succeeded") && p.Restarts == 0 && p.IsReady {
@@ -549,38 +1353,76 @@ func (m model) View() string {
 	if m.state == viewLogs {
 		return m.logsView()
 	}
+	if m.state == viewLogFields {
+		return m.logFieldsView()
+	}
 	if m.state == viewDiagnosis {
 		return m.diagnosisView()
 	}
 	if m.state == viewYaml {
 		return m.yamlView()
 	}
-
-	// HEADER
-	title := headerStyle.Render(" KUBE-PULSE ")
-	cpuPerc := 0
-	memPerc := 0
-	if m.clusterStats.TotalCpuCap > 0 {
-		cpuPerc = int((float64(m.clusterStats.TotalCpuUsage) / float64(m.clusterStats.TotalCpuCap)) * 100)
+	if m.state == viewForwards {
+		return m.forwardsView()
 	}
-	if m.clusterStats.TotalMemCap > 0 {
-		memPerc = int((float64(m.clusterStats.TotalMemUsage) / float64(m.clusterStats.TotalMemCap)) * 100)
+	if m.state == viewApplyConfirm {
+		return m.applyConfirmView()
+	}
+	if m.state == viewWorkloads {
+		return m.workloadsView()
+	}
+	if m.state == viewScaleConfirm {
+		return m.scaleConfirmView()
+	}
+	if m.state == viewHistory {
+		return m.historyView()
+	}
+	if m.state == viewNodes {
+		return m.nodesView()
 	}
-	stats := statsStyle.Render(fmt.Sprintf("  Nodes: %d  |  CPU: %d%%  |  MEMORY: %d%%", m.clusterStats.NodeCount, cpuPerc, memPerc))
-	topBar := fmt.Sprintf("%s%s", title, stats)
+	if m.state == viewDrainConfirm {
+		return m.drainConfirmView()
+	}
+	if m.state == viewDrain {
+		return m.drainView()
+	}
+	if m.state == viewEvictConfirm {
+		return m.evictConfirmView()
+	}
+	if m.state == viewRollbackConfirm {
+		return m.rollbackConfirmView()
+	}
+	if m.state == viewBulkConfirm {
+		return m.bulkConfirmView()
+	}
+	if m.state == viewBulkResult {
+		return m.bulkResultView()
+	}
+
+	// HEADER
+	topBar := m.topBar()
 
 	// CONTEXT BAR
 	var contextInfo string
 	currentNs := m.namespaces[m.currentNsIdx]
+	owner := ""
+	if m.ownerFilter != nil {
+		owner = fmt.Sprintf("  |  OWNER: %s/%s", m.ownerFilter.Kind, m.ownerFilter.Name)
+	} else if m.nodeFilter != "" {
+		owner = "  |  ON NODE: " + m.nodeFilter
+	}
+	if len(m.marked) > 0 {
+		owner += fmt.Sprintf("  |  MARKED: %d", len(m.marked))
+	}
 	if len(m.filteredPods) > 0 && m.cursor < len(m.filteredPods) {
 		sel := m.filteredPods[m.cursor]
 		portStr := "N/A"
 		if sel.Port > 0 {
 			portStr = fmt.Sprintf("%d", sel.Port)
 		}
-		contextInfo = contextStyle.Render(fmt.Sprintf("  Namespace: %s  |  NODE: %s  |  IP: %s  |  PORT: %s", currentNs, sel.NodeName, sel.PodIP, portStr))
+		contextInfo = contextStyle.Render(fmt.Sprintf("  Namespace: %s  |  NODE: %s  |  IP: %s  |  PORT: %s%s", currentNs, sel.NodeName, sel.PodIP, portStr, owner))
 	} else {
-		contextInfo = lipgloss.NewStyle().Foreground(cDim).Render(fmt.Sprintf("  NS: %s  |  No pods found.", currentNs))
+		contextInfo = lipgloss.NewStyle().Foreground(cDim).Render(fmt.Sprintf("  NS: %s  |  No pods found.%s", currentNs, owner))
 	}
 
 	// TABLE
@@ -588,15 +1430,19 @@ func (m model) View() string {
 	w := tabwriter.NewWriter(&b, 0, 0, 3, ' ', 0)
 	fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n", "NAMESPACE", "NAME", "FWD", "READY", "STATUS", "RST", "CPU", "MEM", "NODE", "AGE", "NOTES")
 
-	start, end := m.calculatePagination()
+	start, end := m.calculatePagination(m.cursor, len(m.filteredPods))
 	for i := start; i < end; i++ {
 		p := m.filteredPods[i]
 		fwdStatus := "-"
-		if _, ok := m.activeForwards[p.Namespace+"/"+p.Name]; ok {
-			fwdStatus = "● 8080"
+		if f, ok := m.activeForwards[p.Namespace+"/"+p.Name]; ok {
+			fwdStatus = f.fwdLabel()
 		}
-		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\t%s\t\n",
-			truncate(p.Namespace, 25), truncate(p.Name, 55), fwdStatus, p.Ready, p.Status, p.Restarts, p.CpuUsage, p.MemUsage, truncate(p.NodeName, 15), p.Age, truncate(p.Message, 20))
+		mark := " "
+		if _, ok := m.marked[p.Namespace+"/"+p.Name]; ok {
+			mark = "*"
+		}
+		fmt.Fprintf(w, " %s%s\t%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\t%s\t\n",
+			mark, truncate(p.Namespace, 25), truncate(p.Name, 55), fwdStatus, p.Ready, p.Status, p.Restarts, p.CpuUsage, p.MemUsage, truncate(p.NodeName, 15), p.Age, truncate(p.Message, 20))
 	}
 	w.Flush()
 
@@ -623,34 +1469,59 @@ func (m model) View() string {
 		if i == m.cursor {
 			rowStyle = selectedRowStyle
 			if len(rawLine) > 2 {
-				rawLine = "| " + rawLine[2:]
+				rawLine = "|" + rawLine[1:]
 			}
 		} else {
-			if (p.Status != "Running" && p.Status != "Succeeded") || !p.IsReady {
+			if _, ok := m.marked[p.Namespace+"/"+p.Name]; ok {
+				rowStyle = rowStyle.Foreground(cCyan)
+			} else if (p.Status != "Running" && p.Status != "Succeeded") || !p.IsReady {
 				rowStyle = rowStyle.Foreground(cRed)
 			} else if p.Restarts > 0 {
 				rowStyle = rowStyle.Foreground(cOrange)
 			}
 		}
-		if strings.Contains(rawLine, "●") && i != m.cursor {
-			rawLine = strings.Replace(rawLine, "●", lipgloss.NewStyle().Foreground(cGreen).Render("●"), 1)
+		if i != m.cursor {
+			if strings.Contains(rawLine, "●") {
+				rawLine = strings.Replace(rawLine, "●", lipgloss.NewStyle().Foreground(cGreen).Render("●"), 1)
+			} else if strings.Contains(rawLine, "✖") {
+				rawLine = strings.Replace(rawLine, "✖", lipgloss.NewStyle().Foreground(cRed).Render("✖"), 1)
+			} else if strings.Contains(rawLine, "○") {
+				rawLine = strings.Replace(rawLine, "○", lipgloss.NewStyle().Foreground(cOrange).Render("○"), 1)
+			}
 		}
 		styledRows += rowStyle.Render(rawLine) + "\n"
 		lineIdx++
 	}
 
 	// FOOTER
-	help := footerStyle.Render(fmt.Sprintf("\n  [Tab] Filter (%v)  [n] NS  [?] Doctor  [y] YAML  [s] Shell  [f] Port-Fwd  [C] Cleanse NS  [/] Search  [q] Quit", m.showIssues))
+	help := footerStyle.Render(fmt.Sprintf("\n  [Tab] Filter (%v)  [n] NS  [?] Doctor  [y] YAML  [s] Shell  [b] Debug  [g/u] Copy From/To  [T] Tail Owner  [L] Tail Selector  [f] Port-Fwd  [F] Forwards  [W] Workloads  [N] Nodes  [e] Evict  [Space/a/A] Mark Pod/Page/All  [l] Label  [S] Scale  [H] History  [C] Cleanse NS  [/] Search  [q] Quit", m.showIssues))
 	status := lipgloss.NewStyle().Foreground(cPrimary).Padding(0, 2).Render(m.msg)
 
 	// If Search is active, render search bar overlaid
 	if m.searchActive {
 		return "\n" + topBar + "\n\n" + contextInfo + "\n\n" + styledRows + "\n" + searchStyle.Render("SEARCH: "+m.textInput.View()) + "\n"
 	}
+	if m.promptAction != "" {
+		return "\n" + topBar + "\n\n" + contextInfo + "\n\n" + styledRows + "\n" + m.promptView() + "\n"
+	}
 
 	return "\n" + topBar + "\n\n" + contextInfo + "\n\n" + styledRows + "\n" + help + "\n" + status
 }
 
+func (m model) topBar() string {
+	title := headerStyle.Render(" KUBE-PULSE ")
+	cpuPerc := 0
+	memPerc := 0
+	if m.clusterStats.TotalCpuCap > 0 {
+		cpuPerc = int((float64(m.clusterStats.TotalCpuUsage) / float64(m.clusterStats.TotalCpuCap)) * 100)
+	}
+	if m.clusterStats.TotalMemCap > 0 {
+		memPerc = int((float64(m.clusterStats.TotalMemUsage) / float64(m.clusterStats.TotalMemCap)) * 100)
+	}
+	stats := statsStyle.Render(fmt.Sprintf("  Nodes: %d  |  CPU: %d%%  |  MEMORY: %d%%  |  ", m.clusterStats.NodeCount, cpuPerc, memPerc))
+	return fmt.Sprintf("%s%s%s", title, stats, m.conn.label())
+}
+
 // --- CONTAINER SELECTION MODAL ---
 func (m model) containerSelectView() string {
 	var s strings.Builder
@@ -673,66 +1544,45 @@ func (m model) containerSelectView() string {
 }
 
 func (m model) deleteConfirmView() string {
-	box := modalStyle.Render(fmt.Sprintf("%s\n\nConfirm deletion of:\n%s\n\n%s / %s", lipgloss.NewStyle().Foreground(cRed).Bold(true).Render("[!] DELETE POD"), lipgloss.NewStyle().Foreground(cSecondary).Render(m.podToDelete.Name), lipgloss.NewStyle().Foreground(cGreen).Bold(true).Render("[y] Confirm"), lipgloss.NewStyle().Foreground(cDim).Render("[n] Cancel")))
-	return strings.Repeat("\n", m.height/3) + lipgloss.PlaceHorizontal(m.width, lipgloss.Center, box)
-}
-func (m model) restartConfirmView() string {
-	box := modalStyle.BorderForeground(cOrange).Render(fmt.Sprintf("%s\n\nConfirm restart of:\n%s\n\n%s / %s", lipgloss.NewStyle().Foreground(cOrange).Bold(true).Render("[!] RESTART POD"), lipgloss.NewStyle().Foreground(cSecondary).Render(m.podToDelete.Name), lipgloss.NewStyle().Foreground(cGreen).Bold(true).Render("[y] Confirm"), lipgloss.NewStyle().Foreground(cDim).Render("[n] Cancel")))
-	return strings.Repeat("\n", m.height/3) + lipgloss.PlaceHorizontal(m.width, lipgloss.Center, box)
-}
-func (m model) cleanseConfirmView() string {
-	box := modalStyle.Render(fmt.Sprintf("%s\n\n%s\nNamespace: %s\n\n%s / %s", lipgloss.NewStyle().Foreground(cRed).Bold(true).Blink(true).Render("NUCLEAR WARNING"), lipgloss.NewStyle().Foreground(cSecondary).Render("This will DELETE ALL PODS in:"), lipgloss.NewStyle().Foreground(cRed).Bold(true).Render(m.namespaces[m.currentNsIdx]), lipgloss.NewStyle().Foreground(cGreen).Bold(true).Render("[y] DESTROY ALL"), lipgloss.NewStyle().Foreground(cDim).Render("[n] Cancel")))
-	return strings.Repeat("\n", m.height/3) + lipgloss.PlaceHorizontal(m.width, lipgloss.Center, box)
+	box := modalStyle.Render(fmt.Sprintf("%s\n\nConfirm deletion of:\n%s\n\n%s\n\n%s / %s\n%s", lipgloss.NewStyle().Foreground(cRed).Bold(true).Render("[!] DELETE POD"), lipgloss.NewStyle().Foreground(cSecondary).Render(m.podToDelete.Name), lipgloss.NewStyle().Foreground(cDim).Render(m.deleteOpts.label()), lipgloss.NewStyle().Foreground(cGreen).Bold(true).Render("[y] Confirm"), lipgloss.NewStyle().Foreground(cDim).Render("[n] Cancel"), lipgloss.NewStyle().Foreground(cDim).Render("[g] Grace period  [f] Force")))
+	view := strings.Repeat("\n", m.height/3) + lipgloss.PlaceHorizontal(m.width, lipgloss.Center, box)
+	if m.promptAction != "" {
+		view += "\n\n" + lipgloss.PlaceHorizontal(m.width, lipgloss.Center, m.promptView())
+	}
+	return view
 }
 func (m model) logsView() string {
-	return "\n" + headerStyle.Render(" LOGS: "+m.selectedPod.Name) + "\n\n" + m.viewport.View() + "\n\n" + footerStyle.Render("  [Esc] Back")
+	if m.logStream != nil && m.logStream.tail != nil {
+		title := fmt.Sprintf(" TAIL: %s ", m.logStream.tail.label)
+		return "\n" + headerStyle.Render(title) + " " + lipgloss.NewStyle().Foreground(cCyan).Bold(true).Render(m.logInstance()) + " " + footerStyle.Render(m.logStatus()+" "+m.logRender.status()) + "\n\n" + m.viewport.View() + "\n\n" + m.viewerFooter("[G] Follow  [J] Raw  [v] Level  [K] Fields")
+	}
+	title := fmt.Sprintf(" LOGS: %s [%s] ", m.selectedPod.Name, m.logContainer)
+	instance := lipgloss.NewStyle().Foreground(cCyan).Bold(true).Render(m.logInstance())
+	if m.logPrevious {
+		instance = lipgloss.NewStyle().Foreground(cOrange).Bold(true).Render(m.logInstance())
+	}
+	return "\n" + headerStyle.Render(title) + " " + instance + " " + footerStyle.Render(m.logStatus()+" "+m.logRender.status()) + "\n\n" + m.viewport.View() + "\n\n" + m.viewerFooter("[G] Follow  [p] Previous/Current  [J] Raw  [v] Level  [K] Fields")
 }
 func (m model) diagnosisView() string {
-	return "\n" + diagHeaderStyle.Render(" [DIAGNOSIS]: "+m.selectedPod.Name) + "\n\n" + m.viewport.View() + "\n\n" + footerStyle.Render("  [Esc] Back")
+	return "\n" + diagHeaderStyle.Render(" [DIAGNOSIS]: "+m.selectedPod.Name) + "\n\n" + m.viewport.View() + "\n\n" + m.viewerFooter("")
 }
 func (m model) yamlView() string {
-	return "\n" + yamlHeaderStyle.Render(" [YAML]: "+m.selectedPod.Name) + "\n\n" + m.viewport.View() + "\n\n" + footerStyle.Render("  [Esc] Back")
-}
-
-func openShell(namespace, pod, container, kubeconfig string) tea.Cmd {
-	return tea.ExecProcess(exec.Command("kubectl", "exec", "-it", "-n", namespace, pod, "-c", container, "--", "/bin/sh", "-c", "bash || sh"), func(err error) tea.Msg { return nil })
-}
-func portForward(namespace, pod string, port int32) tea.Cmd {
-	return tea.ExecProcess(exec.Command("sh", "-c", fmt.Sprintf("kubectl port-forward -n %s %s 8080:%d", namespace, pod, port)), func(err error) tea.Msg { return nil })
-}
-func fetchYaml(namespace, pod string) tea.Cmd {
-	return func() tea.Msg {
-		cmd := exec.Command("kubectl", "get", "pod", pod, "-n", namespace, "-o", "yaml")
-		var out bytes.Buffer
-		cmd.Stdout = &out
-		if err := cmd.Run(); err != nil {
-			return yamlMsg(fmt.Sprintf("Error: %v", err))
-		}
-		return yamlMsg(out.String())
-	}
-}
-func cleanseNamespace(client *kubernetes.Clientset, namespace string) tea.Cmd {
-	return func() tea.Msg {
-		if err := client.CoreV1().Pods(namespace).DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{}); err != nil {
-			return deleteMsg(fmt.Sprintf("Cleanse failed: %v", err))
-		}
-		return deleteMsg(fmt.Sprintf("ALL PODS IN '%s' HAVE BEEN DELETED.", namespace))
-	}
+	return "\n" + yamlHeaderStyle.Render(" [YAML]: "+m.selectedPod.Name) + " " + footerStyle.Render(m.yamlOpts.label()) + "\n\n" + m.viewport.View() + "\n\n" + m.viewerFooter("[e] Edit  [M] managedFields  [S] Status  [o] YAML/JSON  [z] Fold  [Z] Unfold")
 }
 
 // --- HELPERS ---
-func (m model) calculatePagination() (int, int) {
+func (m model) calculatePagination(cursor, total int) (int, int) {
 	perPage := m.height - 12
 	if perPage <= 0 {
 		perPage = 5
 	}
-	start, end := 0, len(m.filteredPods)
-	if len(m.filteredPods) > perPage {
-		if m.cursor < perPage {
+	start, end := 0, total
+	if total > perPage {
+		if cursor < perPage {
 			end = perPage
 		} else {
-			start = m.cursor - perPage + 1
-			end = m.cursor + 1
+			start = cursor - perPage + 1
+			end = cursor + 1
 		}
 	}
 	return start, end
@@ -755,76 +1605,58 @@ func shortAge(d time.Duration) string {
 	}
 	return fmt.Sprintf("%ds", int(d.Seconds()))
 }
-func tick() tea.Cmd { return tea.Tick(3*time.Second, func(t time.Time) tea.Msg { return tickMsg(t) }) }
+
+// Pods, nodes and namespaces come from informers; only metrics-server is polled.
+func tick() tea.Cmd { return tea.Tick(15*time.Second, func(t time.Time) tea.Msg { return tickMsg(t) }) }
 
 type tickMsg time.Time
-type podsMsg []PodInfo
-type statsMsg ClusterStats
-type nsMsg []string
-type logsMsg string
+type metricsMsg struct {
+	pods             map[string]corev1.ResourceList
+	nodes            map[string]corev1.ResourceList
+	nodeCpu, nodeMem int64
+	nodesOk          bool
+}
 type diagMsg string
-type yamlMsg string
 type deleteMsg string
 
 // --- ASYNC DATA FETCHING ---
-func fetchNamespaces(c *kubernetes.Clientset) tea.Cmd {
-	return func() tea.Msg {
-		l, e := c.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
-		if e != nil {
-			return nil
-		}
-		var n []string
-		for _, i := range l.Items {
-			n = append(n, i.Name)
-		}
-		sort.Strings(n)
-		return nsMsg(n)
-	}
-}
-func fetchClusterStats(c *kubernetes.Clientset, m *metricsv.Clientset) tea.Cmd {
+func fetchMetrics(m *metricsv.Clientset) tea.Cmd {
 	return func() tea.Msg {
-		nodes, err := c.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
-		if err != nil {
-			return statsMsg(ClusterStats{})
-		}
-		totalCpuCap := int64(0)
-		totalMemCap := int64(0)
-		for _, n := range nodes.Items {
-			totalCpuCap += n.Status.Allocatable.Cpu().MilliValue()
-			totalMemCap += n.Status.Allocatable.Memory().Value()
+		out := metricsMsg{pods: make(map[string]corev1.ResourceList), nodes: make(map[string]corev1.ResourceList)}
+		mList, _ := m.MetricsV1beta1().PodMetricses("").List(context.TODO(), metav1.ListOptions{})
+		if mList != nil {
+			for _, i := range mList.Items {
+				cT, mT := resource.Quantity{}, resource.Quantity{}
+				for _, c := range i.Containers {
+					cT.Add(*c.Usage.Cpu())
+					mT.Add(*c.Usage.Memory())
+				}
+				out.pods[i.Namespace+"/"+i.Name] = corev1.ResourceList{corev1.ResourceCPU: cT, corev1.ResourceMemory: mT}
+			}
 		}
 		nodeMetrics, _ := m.MetricsV1beta1().NodeMetricses().List(context.TODO(), metav1.ListOptions{})
-		totalCpuUse := int64(0)
-		totalMemUse := int64(0)
 		if nodeMetrics != nil {
+			out.nodesOk = true
 			for _, nm := range nodeMetrics.Items {
-				totalCpuUse += nm.Usage.Cpu().MilliValue()
-				totalMemUse += nm.Usage.Memory().Value()
+				out.nodeCpu += nm.Usage.Cpu().MilliValue()
+				out.nodeMem += nm.Usage.Memory().Value()
+				out.nodes[nm.Name] = nm.Usage
 			}
 		}
-		return statsMsg(ClusterStats{TotalCpuUsage: totalCpuUse, TotalMemUsage: totalMemUse, TotalCpuCap: totalCpuCap, TotalMemCap: totalMemCap, NodeCount: len(nodes.Items)})
+		return out
 	}
 }
-func fetchLogs(c *kubernetes.Clientset, p PodInfo, container string) tea.Cmd {
+func deletePod(c *kubernetes.Clientset, p PodInfo, opts metav1.DeleteOptions) tea.Cmd {
 	return func() tea.Msg {
-		req := c.CoreV1().Pods(p.Namespace).GetLogs(p.Name, &corev1.PodLogOptions{Container: container, TailLines: func(i int64) *int64 { return &i }(100)})
-		stream, err := req.Stream(context.TODO())
-		if err != nil {
-			return logsMsg("Error fetching logs")
+		if err := c.CoreV1().Pods(p.Namespace).Delete(context.TODO(), p.Name, opts); err != nil {
+			return apiErrMsg{action: "Delete " + p.Name, err: err}
 		}
-		defer stream.Close()
-		buf := new(bytes.Buffer)
-		io.Copy(buf, stream)
-		return logsMsg(buf.String())
+		return deleteMsg(fmt.Sprintf("Deleted %s.", p.Name))
 	}
 }
-func deletePod(c *kubernetes.Clientset, p PodInfo) tea.Cmd {
-	return func() tea.Msg {
-		c.CoreV1().Pods(p.Namespace).Delete(context.TODO(), p.Name, metav1.DeleteOptions{})
-		return deleteMsg("Pod deleted.")
-	}
-}
-func diagnosePod(client *kubernetes.Clientset, pod PodInfo) tea.Cmd {
+
+// raw is the cached pod object; it may be nil if the pod is already gone.
+func diagnosePod(client *kubernetes.Clientset, pod PodInfo, raw *corev1.Pod) tea.Cmd {
 	return func() tea.Msg {
 		events, err := client.CoreV1().Events(pod.Namespace).List(context.TODO(), metav1.ListOptions{FieldSelector: fmt.Sprintf("involvedObject.name=%s,involvedObject.kind=Pod", pod.Name)})
 		var report strings.Builder
@@ -857,83 +1689,87 @@ func diagnosePod(client *kubernetes.Clientset, pod PodInfo) tea.Cmd {
 			io.Copy(buf, stream)
 			report.WriteString(lipgloss.NewStyle().Foreground(cDim).Render(buf.String()))
 		}
+		// Crash-looping containers usually log nothing useful in their
+		// current instance; the terminated one holds the stack trace.
+		if raw != nil {
+			for _, c := range pod.Containers {
+				term := lastTermination(raw, c)
+				if term == "" {
+					continue
+				}
+				report.WriteString("\n" + diagTitleStyle.Render(fmt.Sprintf("[PREVIOUS LOGS: %s]", c)) + " " + lipgloss.NewStyle().Foreground(cOrange).Render(term) + "\n")
+				prev := client.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{Container: c, Previous: true, TailLines: func(i int64) *int64 { return &i }(15)})
+				if out, err := prev.DoRaw(context.TODO()); err == nil {
+					report.WriteString(lipgloss.NewStyle().Foreground(cDim).Render(string(out)))
+				} else {
+					report.WriteString(fmt.Sprintf("Unavailable: %v\n", err))
+				}
+			}
+		}
 		return diagMsg(report.String())
 	}
 }
 
-func fetchPods(c *kubernetes.Clientset, m *metricsv.Clientset) tea.Cmd {
-	return func() tea.Msg {
-		pList, e := c.CoreV1().Pods("").List(context.TODO(), metav1.ListOptions{})
-		if e != nil {
-			return nil
+func podInfoFromPod(p *corev1.Pod, usage map[string]corev1.ResourceList) PodInfo {
+	r := int32(0)
+	ready := 0
+	total := len(p.Status.ContainerStatuses)
+	msg := "[OK]"
+	var port int32 = 0
+	if len(p.Spec.Containers) > 0 && len(p.Spec.Containers[0].Ports) > 0 {
+		port = p.Spec.Containers[0].Ports[0].ContainerPort
+	}
+	var containerNames []string
+	for _, c := range p.Spec.Containers {
+		containerNames = append(containerNames, c.Name)
+	}
+
+	for _, c := range p.Status.ContainerStatuses {
+		r += c.RestartCount
+		if c.Ready {
+			ready++
 		}
-		uMap := make(map[string]corev1.ResourceList)
-		mList, _ := m.MetricsV1beta1().PodMetricses("").List(context.TODO(), metav1.ListOptions{})
-		if mList != nil {
-			for _, i := range mList.Items {
-				cT, mT := resource.Quantity{}, resource.Quantity{}
-				for _, c := range i.Containers {
-					cT.Add(*c.Usage.Cpu())
-					mT.Add(*c.Usage.Memory())
-				}
-				uMap[i.Namespace+"/"+i.Name] = corev1.ResourceList{corev1.ResourceCPU: cT, corev1.ResourceMemory: mT}
+		if c.State.Waiting != nil && c.State.Waiting.Reason != "" {
+			msg = c.State.Waiting.Reason
+		} else if c.State.Terminated != nil && c.State.Terminated.Reason != "" {
+			msg = c.State.Terminated.Reason
+			if c.State.Terminated.ExitCode != 0 {
+				msg = fmt.Sprintf("%s (%d)", msg, c.State.Terminated.ExitCode)
 			}
 		}
+	}
+	if p.Status.Phase == "Running" && ready == total {
+		msg = "[OK]"
+	}
+	if p.Status.Phase == "Succeeded" {
+		msg = "Completed"
+	}
 
-		var list []PodInfo
-		for _, p := range pList.Items {
-			r := int32(0)
-			ready := 0
-			total := len(p.Status.ContainerStatuses)
-			msg := "[OK]"
-			var port int32 = 0
-			if len(p.Spec.Containers) > 0 && len(p.Spec.Containers[0].Ports) > 0 {
-				port = p.Spec.Containers[0].Ports[0].ContainerPort
-			}
-			var containerNames []string
-			for _, c := range p.Spec.Containers {
-				containerNames = append(containerNames, c.Name)
-			}
-
-			for _, c := range p.Status.ContainerStatuses {
-				r += c.RestartCount
-				if c.Ready {
-					ready++
-				}
-				if c.State.Waiting != nil && c.State.Waiting.Reason != "" {
-					msg = c.State.Waiting.Reason
-				} else if c.State.Terminated != nil && c.State.Terminated.Reason != "" {
-					msg = c.State.Terminated.Reason
-					if c.State.Terminated.ExitCode != 0 {
-						msg = fmt.Sprintf("%s (%d)", msg, c.State.Terminated.ExitCode)
-					}
-				}
-			}
-			if p.Status.Phase == "Running" && ready == total {
-				msg = "[OK]"
-			}
-			if p.Status.Phase == "Succeeded" {
-				msg = "Completed"
-			}
+	isReady := (ready == total && total > 0) || (p.Status.Phase == "Succeeded")
+	readyStr := fmt.Sprintf("%d/%d", ready, total)
 
-			var rawCpu, rawMem int64 = 0, 0
-			cStr, mStr := "-", "-"
-			if u, ok := uMap[p.Namespace+"/"+p.Name]; ok {
-				rawCpu = u.Cpu().MilliValue()
-				rawMem = u.Memory().Value()
-				cStr = fmt.Sprintf("%dm", rawCpu)
-				mStr = fmt.Sprintf("%dMi", rawMem/(1024*1024))
-			}
-			isReady := (ready == total && total > 0) || (p.Status.Phase == "Succeeded")
-			readyStr := fmt.Sprintf("%d/%d", ready, total)
-			age := shortAge(time.Since(p.CreationTimestamp.Time))
+	info := PodInfo{
+		Namespace: p.Namespace, Name: p.Name, Ready: readyStr, Status: string(p.Status.Phase),
+		Restarts: r, NodeName: p.Spec.NodeName, PodIP: p.Status.PodIP, IsReady: isReady, Message: msg, Port: port,
+		Created: p.CreationTimestamp.Time, Containers: containerNames,
+	}
+	if ref := metav1.GetControllerOf(p); ref != nil {
+		info.OwnerUID = ref.UID
+	}
+	info.applyUsage(usage)
+	return info
+}
 
-			list = append(list, PodInfo{
-				Namespace: p.Namespace, Name: p.Name, Ready: readyStr, Status: string(p.Status.Phase),
-				Restarts: r, CpuUsage: cStr, MemUsage: mStr, RawCpu: rawCpu, RawMem: rawMem,
-				NodeName: p.Spec.NodeName, PodIP: p.Status.PodIP, IsReady: isReady, Message: msg, Port: port, Age: age, Containers: containerNames,
-			})
-		}
-		return podsMsg(list)
+// applyUsage refreshes the metrics columns and the age, which both drift
+// between informer events.
+func (p *PodInfo) applyUsage(usage map[string]corev1.ResourceList) {
+	p.RawCpu, p.RawMem = 0, 0
+	p.CpuUsage, p.MemUsage = "-", "-"
+	if u, ok := usage[p.Namespace+"/"+p.Name]; ok {
+		p.RawCpu = u.Cpu().MilliValue()
+		p.RawMem = u.Memory().Value()
+		p.CpuUsage = fmt.Sprintf("%dm", p.RawCpu)
+		p.MemUsage = fmt.Sprintf("%dMi", p.RawMem/(1024*1024))
 	}
-}
\ No newline at end of file
+	p.Age = shortAge(time.Since(p.Created))
+}
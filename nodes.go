@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// --- NODES ---
+type NodeInfo struct {
+	Name          string
+	Roles         string
+	Version       string
+	Ready         bool
+	Conditions    string // Ready/NotReady plus any pressure, like kubectl
+	Pressure      bool
+	Unschedulable bool
+	Taints        string
+	Pods          int
+	PodCap        int64
+	CpuUsage      int64 // Millicores; -1 without metrics
+	CpuAlloc      int64
+	MemUsage      int64 // Bytes; -1 without metrics
+	MemAlloc      int64
+	Age           string
+}
+
+func nodeInfoFromNode(n *corev1.Node, pods int, usage map[string]corev1.ResourceList) NodeInfo {
+	info := NodeInfo{
+		Name: n.Name, Version: n.Status.NodeInfo.KubeletVersion, Unschedulable: n.Spec.Unschedulable,
+		Pods: pods, PodCap: n.Status.Allocatable.Pods().Value(),
+		CpuAlloc: n.Status.Allocatable.Cpu().MilliValue(), MemAlloc: n.Status.Allocatable.Memory().Value(),
+		CpuUsage: -1, MemUsage: -1, Age: shortAge(time.Since(n.CreationTimestamp.Time)),
+	}
+	var roles []string
+	for k, v := range n.Labels {
+		if r, ok := strings.CutPrefix(k, "node-role.kubernetes.io/"); ok && r != "" {
+			roles = append(roles, r)
+		} else if k == "kubernetes.io/role" && v != "" {
+			roles = append(roles, v)
+		}
+	}
+	sort.Strings(roles)
+	info.Roles = strings.Join(roles, ",")
+	if info.Roles == "" {
+		info.Roles = "<none>"
+	}
+
+	conds := []string{"NotReady"}
+	for _, c := range n.Status.Conditions {
+		switch c.Type {
+		case corev1.NodeReady:
+			if c.Status == corev1.ConditionTrue {
+				info.Ready, conds[0] = true, "Ready"
+			} else if c.Status == corev1.ConditionUnknown {
+				conds[0] = "Unknown"
+			}
+		case corev1.NodeMemoryPressure, corev1.NodeDiskPressure, corev1.NodePIDPressure:
+			if c.Status == corev1.ConditionTrue {
+				info.Pressure = true
+				conds = append(conds, string(c.Type))
+			}
+		}
+	}
+	if n.Spec.Unschedulable {
+		conds = append(conds, "SchedulingDisabled")
+	}
+	info.Conditions = strings.Join(conds, ",")
+
+	var taints []string
+	for _, t := range n.Spec.Taints {
+		s := t.Key
+		if t.Value != "" {
+			s += "=" + t.Value
+		}
+		taints = append(taints, s+":"+string(t.Effect))
+	}
+	info.Taints = strings.Join(taints, ",")
+	if info.Taints == "" {
+		info.Taints = "-"
+	}
+
+	if u, ok := usage[n.Name]; ok {
+		info.CpuUsage, info.MemUsage = u.Cpu().MilliValue(), u.Memory().Value()
+	}
+	return info
+}
+
+// loadNodes rebuilds the node rows from the informer cache, counting the
+// non-terminated pods scheduled on each.
+func (m *model) loadNodes() {
+	counts := make(map[string]int)
+	for _, p := range m.pods {
+		if p.NodeName != "" && p.Status != "Succeeded" && p.Status != "Failed" {
+			counts[p.NodeName]++
+		}
+	}
+	m.nodes = m.nodes[:0]
+	for _, n := range m.watcher.listNodes() {
+		m.nodes = append(m.nodes, nodeInfoFromNode(n, counts[n.Name], m.nodeUsage))
+	}
+	sort.Slice(m.nodes, func(i, j int) bool { return m.nodes[i].Name < m.nodes[j].Name })
+	if m.nodeCursor >= len(m.nodes) {
+		m.nodeCursor = max(len(m.nodes)-1, 0)
+	}
+}
+
+func usageCell(used, alloc int64, format func(int64) string) string {
+	if used < 0 || alloc <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d%% %s/%s", used*100/alloc, format(used), format(alloc))
+}
+
+func cores(milli int64) string { return fmt.Sprintf("%.1f", float64(milli)/1000) }
+
+func (m model) updateNodes(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m.quit()
+	case "esc", "N":
+		m.state = viewList
+	case "up", "k":
+		if m.nodeCursor > 0 {
+			m.nodeCursor--
+		}
+	case "down", "j":
+		if m.nodeCursor < len(m.nodes)-1 {
+			m.nodeCursor++
+		}
+	case "c", "u":
+		if len(m.nodes) > 0 {
+			n := m.nodes[m.nodeCursor]
+			return m, cordonNode(m.client, n.Name, msg.String() == "c")
+		}
+	case "D":
+		if len(m.nodes) == 0 {
+			return m, nil
+		}
+		n := m.nodes[m.nodeCursor]
+		if m.drain != nil && !m.drain.done {
+			if m.drain.node == n.Name {
+				m.state = viewDrain
+			} else {
+				m.msg = fmt.Sprintf("Already draining %s.", m.drain.node)
+			}
+			return m, nil
+		}
+		m.drain = m.planDrain(n.Name)
+		m.state = viewDrainConfirm
+	case "enter":
+		if len(m.nodes) > 0 {
+			m.nodeFilter, m.ownerFilter = m.nodes[m.nodeCursor].Name, nil
+			m.state = viewList
+			m.cursor = 0
+			m.filterPods()
+			m.msg = fmt.Sprintf("Pods on %s", m.nodeFilter)
+		}
+	}
+	return m, nil
+}
+
+func (m model) nodesView() string {
+	contextInfo := contextStyle.Render(fmt.Sprintf("  NODES: %d", len(m.nodes)))
+
+	var b bytes.Buffer
+	tw := tabwriter.NewWriter(&b, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n", "NAME", "STATUS", "ROLES", "VERSION", "PODS", "CPU", "MEM", "TAINTS", "AGE")
+	start, end := m.calculatePagination(m.nodeCursor, len(m.nodes))
+	for i := start; i < end; i++ {
+		n := m.nodes[i]
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%d/%d\t%s\t%s\t%s\t%s\t\n",
+			truncate(n.Name, 40), n.Conditions, truncate(n.Roles, 20), n.Version, n.Pods, n.PodCap,
+			usageCell(n.CpuUsage, n.CpuAlloc, cores), usageCell(n.MemUsage, n.MemAlloc, humanBytes), truncate(n.Taints, 40), n.Age)
+	}
+	tw.Flush()
+
+	lines := strings.Split(b.String(), "\n")
+	rows := colHeadStyle.Render(lines[0]) + "\n"
+	for i := start; i < end && i-start+1 < len(lines); i++ {
+		line := lines[i-start+1]
+		n := m.nodes[i]
+		style := lipgloss.NewStyle().Foreground(cSecondary)
+		switch {
+		case i == m.nodeCursor:
+			style = selectedRowStyle
+			if len(line) > 2 {
+				line = "| " + line[2:]
+			}
+		case !n.Ready:
+			style = style.Foreground(cRed)
+		case n.Pressure || n.Unschedulable:
+			style = style.Foreground(cOrange)
+		}
+		rows += style.Render(line) + "\n"
+	}
+
+	help := footerStyle.Render("\n  [Enter] Pods on Node  [c] Cordon  [u] Uncordon  [D] Drain  [Esc] Back to Pods  [q] Quit")
+	status := lipgloss.NewStyle().Foreground(cPrimary).Padding(0, 2).Render(m.msg)
+	return "\n" + m.topBar() + "\n\n" + contextInfo + "\n\n" + rows + "\n" + help + "\n" + status
+}
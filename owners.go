@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// --- OWNER RESOLUTION ---
+// workloadRef identifies the top-level controller of a pod.
+type workloadRef struct {
+	Kind      string // Deployment, StatefulSet, DaemonSet, ReplicaSet, Job...
+	Namespace string
+	Name      string
+	Selector  labels.Selector
+}
+
+func (w workloadRef) String() string { return fmt.Sprintf("%s/%s", w.Kind, w.Name) }
+
+// resolveOwner follows the pod's controller reference, walking ReplicaSet to
+// Deployment, and returns the workload with its pod selector.
+func resolveOwner(c *kubernetes.Clientset, p *corev1.Pod) (workloadRef, error) {
+	ctx := context.TODO()
+	ref := metav1.GetControllerOf(p)
+	if ref == nil {
+		return workloadRef{}, fmt.Errorf("%s has no controller", p.Name)
+	}
+	w := workloadRef{Kind: ref.Kind, Namespace: p.Namespace, Name: ref.Name}
+	var sel *metav1.LabelSelector
+	switch ref.Kind {
+	case "ReplicaSet":
+		rs, err := c.AppsV1().ReplicaSets(p.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return workloadRef{}, err
+		}
+		sel = rs.Spec.Selector
+		if dref := metav1.GetControllerOf(rs); dref != nil && dref.Kind == "Deployment" {
+			d, err := c.AppsV1().Deployments(p.Namespace).Get(ctx, dref.Name, metav1.GetOptions{})
+			if err != nil {
+				return workloadRef{}, err
+			}
+			w.Kind, w.Name, sel = "Deployment", d.Name, d.Spec.Selector
+		}
+	case "StatefulSet":
+		s, err := c.AppsV1().StatefulSets(p.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return workloadRef{}, err
+		}
+		sel = s.Spec.Selector
+	case "DaemonSet":
+		d, err := c.AppsV1().DaemonSets(p.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return workloadRef{}, err
+		}
+		sel = d.Spec.Selector
+	case "Job":
+		j, err := c.BatchV1().Jobs(p.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return workloadRef{}, err
+		}
+		sel = j.Spec.Selector
+	default:
+		return workloadRef{}, fmt.Errorf("unsupported owner kind %s", ref.Kind)
+	}
+	s, err := metav1.LabelSelectorAsSelector(sel)
+	if err != nil {
+		return workloadRef{}, err
+	}
+	w.Selector = s
+	return w, nil
+}
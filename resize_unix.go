@@ -0,0 +1,32 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// newSizeQueue sends the initial size and one more on every SIGWINCH.
+func newSizeQueue(fd int) *sizeQueue {
+	q := &sizeQueue{ch: make(chan remotecommand.TerminalSize, 1), stopCh: make(chan struct{})}
+	q.push(fd)
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGWINCH)
+	go func() {
+		defer close(q.ch)
+		defer signal.Stop(sig)
+		for {
+			select {
+			case <-sig:
+				q.push(fd)
+			case <-q.stopCh:
+				return
+			}
+		}
+	}()
+	return q
+}
@@ -0,0 +1,17 @@
+//go:build windows
+
+package main
+
+import "k8s.io/client-go/tools/remotecommand"
+
+// newSizeQueue sends the initial size only; Windows consoles have no
+// SIGWINCH to watch.
+func newSizeQueue(fd int) *sizeQueue {
+	q := &sizeQueue{ch: make(chan remotecommand.TerminalSize, 1), stopCh: make(chan struct{})}
+	q.push(fd)
+	go func() {
+		<-q.stopCh
+		close(q.ch)
+	}()
+	return q
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// --- ROLLOUT RESTART ---
+// "r" restarts the pod's workload the way `kubectl rollout restart` does:
+// bumping an annotation on the pod template so the controller replaces
+// every pod. Pods whose controller has no rollout (a bare ReplicaSet, a
+// Job) are deleted instead and recreated by it; bare pods are left alone.
+type restartPlan struct {
+	pod     PodInfo
+	owner   workloadRef // Zero for pods without a controller
+	rollout bool
+}
+
+type restartPlanMsg struct {
+	plan restartPlan
+	err  error
+}
+
+// rolloutWatch follows a workload after a restart or scale until its status
+// catches up with the generation we produced.
+type rolloutWatch struct {
+	verb       string // "Restart", "Scale"
+	kind       string
+	namespace  string
+	name       string
+	generation int64
+	replicas   int32 // Scale target
+}
+
+type rolloutStartedMsg struct {
+	watch rolloutWatch
+	err   error
+}
+
+func resolveRestart(c *kubernetes.Clientset, pod PodInfo, raw *corev1.Pod) tea.Cmd {
+	return func() tea.Msg {
+		plan, err := planRestart(c, pod, raw)
+		return restartPlanMsg{plan: plan, err: err}
+	}
+}
+
+// planRestart decides how the pod is restarted. raw may be nil, in which
+// case the pod is fetched.
+func planRestart(c *kubernetes.Clientset, pod PodInfo, raw *corev1.Pod) (restartPlan, error) {
+	plan := restartPlan{pod: pod}
+	if raw == nil {
+		p, err := c.CoreV1().Pods(pod.Namespace).Get(context.TODO(), pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return plan, err
+		}
+		raw = p
+	}
+	ref := metav1.GetControllerOf(raw)
+	if ref == nil {
+		return plan, nil
+	}
+	switch ref.Kind {
+	case "ReplicaSet", "StatefulSet", "DaemonSet":
+		owner, err := resolveOwner(c, raw)
+		if err != nil {
+			return plan, err
+		}
+		plan.owner = owner
+		plan.rollout = owner.Kind != "ReplicaSet"
+	default:
+		plan.owner = workloadRef{Kind: ref.Kind, Namespace: raw.Namespace, Name: ref.Name}
+	}
+	return plan, nil
+}
+
+// rolloutRestart patches kubectl's restartedAt annotation into the owner's
+// pod template.
+func rolloutRestart(c *kubernetes.Clientset, w workloadRef) tea.Cmd {
+	return func() tea.Msg {
+		meta, err := patchRestartedAt(c, w)
+		if err != nil {
+			return rolloutStartedMsg{watch: rolloutWatch{verb: "Restart"}, err: err}
+		}
+		return rolloutStartedMsg{watch: rolloutWatch{verb: "Restart", kind: w.Kind, namespace: w.Namespace, name: w.Name, generation: meta.GetGeneration()}}
+	}
+}
+
+func patchRestartedAt(c *kubernetes.Clientset, w workloadRef) (metav1.Object, error) {
+	ctx := context.TODO()
+	patch := []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":%q}}}}}`, time.Now().Format(time.RFC3339)))
+	opts := metav1.PatchOptions{FieldManager: "kube-pulse"}
+	switch w.Kind {
+	case "Deployment":
+		return c.AppsV1().Deployments(w.Namespace).Patch(ctx, w.Name, types.StrategicMergePatchType, patch, opts)
+	case "StatefulSet":
+		return c.AppsV1().StatefulSets(w.Namespace).Patch(ctx, w.Name, types.StrategicMergePatchType, patch, opts)
+	case "DaemonSet":
+		return c.AppsV1().DaemonSets(w.Namespace).Patch(ctx, w.Name, types.StrategicMergePatchType, patch, opts)
+	}
+	return nil, fmt.Errorf("%s cannot be rollout-restarted", w)
+}
+
+// trackRollout reports the watched workload's progress from the informer
+// cache and clears the watch once it has settled.
+func (m *model) trackRollout() {
+	r := m.rollout
+	if r == nil {
+		return
+	}
+	if r.verb == "Scale" {
+		m.trackScale()
+		return
+	}
+	for _, w := range m.workloads {
+		if w.Kind != r.kind || w.Namespace != r.namespace || w.Name != r.name {
+			continue
+		}
+		label := fmt.Sprintf("%s %s/%s", r.verb, r.kind, r.name)
+		switch {
+		case w.ObservedGeneration < r.generation:
+			m.msg = label + ": waiting for the controller..."
+		case w.Settled && w.Ready >= w.Desired:
+			m.msg = fmt.Sprintf("%s complete (%d/%d ready).", label, w.Ready, w.Desired)
+			m.rollout = nil
+		default:
+			m.msg = fmt.Sprintf("%s: %s, %d/%d ready", label, w.Rollout, w.Ready, w.Desired)
+		}
+		return
+	}
+}
+
+func (m model) restartConfirmView() string {
+	p := m.restart
+	title := lipgloss.NewStyle().Foreground(cOrange).Bold(true).Render("[!] RESTART POD")
+	var explain string
+	keys := lipgloss.NewStyle().Foreground(cGreen).Bold(true).Render("[y] Confirm") + " / " + lipgloss.NewStyle().Foreground(cDim).Render("[n] Cancel")
+	switch {
+	case p.rollout:
+		title = lipgloss.NewStyle().Foreground(cOrange).Bold(true).Render("[!] ROLLOUT RESTART")
+		explain = fmt.Sprintf("Owned by %s.\nAll of its pods will be replaced\nfollowing its update strategy.", p.owner)
+	case p.owner.Kind != "":
+		explain = fmt.Sprintf("Owned by %s, which has no rollout.\nThe pod will be deleted and\nrecreated by its controller.", p.owner)
+	default:
+		explain = "This pod has no controller.\nDeleting it would not bring it back;\nuse [d] if that is what you want."
+		keys = lipgloss.NewStyle().Foreground(cDim).Render("[n] Close")
+	}
+	lines := []string{title, "", "Confirm restart of:", lipgloss.NewStyle().Foreground(cSecondary).Render(p.pod.Name), "", lipgloss.NewStyle().Foreground(cDim).Render(explain), "", keys}
+	box := modalStyle.BorderForeground(cOrange).Render(strings.Join(lines, "\n"))
+	return strings.Repeat("\n", m.height/3) + lipgloss.PlaceHorizontal(m.width, lipgloss.Center, box)
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// --- SCALE ---
+// Deployments, StatefulSets and ReplicaSets are scaled through their scale
+// subresource, from a pod's owner or from the workloads view.
+type scalePlan struct {
+	owner    workloadRef
+	current  int32
+	replicas int32
+}
+
+type scaleTargetMsg struct {
+	owner   workloadRef
+	current int32
+	err     error
+}
+
+func getScale(c *kubernetes.Clientset, w workloadRef) (*autoscalingv1.Scale, error) {
+	ctx := context.TODO()
+	switch w.Kind {
+	case "Deployment":
+		return c.AppsV1().Deployments(w.Namespace).GetScale(ctx, w.Name, metav1.GetOptions{})
+	case "StatefulSet":
+		return c.AppsV1().StatefulSets(w.Namespace).GetScale(ctx, w.Name, metav1.GetOptions{})
+	case "ReplicaSet":
+		return c.AppsV1().ReplicaSets(w.Namespace).GetScale(ctx, w.Name, metav1.GetOptions{})
+	}
+	return nil, fmt.Errorf("%s cannot be scaled", w)
+}
+
+func updateScale(c *kubernetes.Clientset, w workloadRef, s *autoscalingv1.Scale) error {
+	ctx := context.TODO()
+	opts := metav1.UpdateOptions{FieldManager: "kube-pulse"}
+	var err error
+	switch w.Kind {
+	case "Deployment":
+		_, err = c.AppsV1().Deployments(w.Namespace).UpdateScale(ctx, w.Name, s, opts)
+	case "StatefulSet":
+		_, err = c.AppsV1().StatefulSets(w.Namespace).UpdateScale(ctx, w.Name, s, opts)
+	case "ReplicaSet":
+		_, err = c.AppsV1().ReplicaSets(w.Namespace).UpdateScale(ctx, w.Name, s, opts)
+	default:
+		err = fmt.Errorf("%s cannot be scaled", w)
+	}
+	return err
+}
+
+// resolveScaleTarget finds the pod's scalable owner (raw may be nil) or,
+// with a workload given, reads its current replica count.
+func resolveScaleTarget(c *kubernetes.Clientset, raw *corev1.Pod, owner workloadRef) tea.Cmd {
+	return func() tea.Msg {
+		if raw != nil {
+			o, err := resolveOwner(c, raw)
+			if err != nil {
+				return scaleTargetMsg{err: err}
+			}
+			owner = o
+		}
+		s, err := getScale(c, owner)
+		if err != nil {
+			return scaleTargetMsg{err: err}
+		}
+		return scaleTargetMsg{owner: owner, current: s.Spec.Replicas}
+	}
+}
+
+func applyScale(c *kubernetes.Clientset, p scalePlan) tea.Cmd {
+	return func() tea.Msg {
+		s, err := getScale(c, p.owner)
+		if err != nil {
+			return rolloutStartedMsg{watch: rolloutWatch{verb: "Scale"}, err: err}
+		}
+		s.Spec.Replicas = p.replicas
+		if err := updateScale(c, p.owner, s); err != nil {
+			return rolloutStartedMsg{watch: rolloutWatch{verb: "Scale"}, err: err}
+		}
+		return rolloutStartedMsg{watch: rolloutWatch{verb: "Scale", kind: p.owner.Kind, namespace: p.owner.Namespace, name: p.owner.Name, replicas: p.replicas}}
+	}
+}
+
+// submitScale validates the replica count and opens the confirm modal.
+func (m *model) submitScale(value string) {
+	n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
+	if err != nil || n < 0 || m.scale == nil {
+		m.msg = fmt.Sprintf("Invalid replica count %q", value)
+		m.scale = nil
+		return
+	}
+	m.scale.replicas = int32(n)
+	m.state = viewScaleConfirm
+}
+
+func (m *model) openScalePrompt(msg scaleTargetMsg) tea.Cmd {
+	if msg.err != nil {
+		m.msg = fmt.Sprintf("Scale failed: %v", msg.err)
+		return nil
+	}
+	m.scale = &scalePlan{owner: msg.owner, current: msg.current}
+	m.msg = fmt.Sprintf("%s has %d replicas", msg.owner, msg.current)
+	m.openPrompt("scale", "replicas for "+msg.owner.String(), strconv.Itoa(int(msg.current)))
+	return textinput.Blink
+}
+
+// trackScale follows replicas converging on the target. ReplicaSets are
+// not in the workloads list, so they are read from the informer directly.
+func (m *model) trackScale() {
+	r := m.rollout
+	var desired, ready, available int32
+	found := false
+	if r.kind == "ReplicaSet" {
+		if rs, err := m.watcher.replicaSets.ReplicaSets(r.namespace).Get(r.name); err == nil && rs.Spec.Replicas != nil {
+			desired, ready, available, found = *rs.Spec.Replicas, rs.Status.ReadyReplicas, rs.Status.AvailableReplicas, true
+		}
+	}
+	for _, w := range m.workloads {
+		if w.Kind == r.kind && w.Namespace == r.namespace && w.Name == r.name {
+			desired, ready, available, found = w.Desired, w.Ready, w.Available, true
+		}
+	}
+	if !found {
+		return
+	}
+	label := fmt.Sprintf("Scale %s/%s", r.kind, r.name)
+	switch {
+	case desired != r.replicas:
+		m.msg = label + ": waiting for the controller..."
+	case ready == r.replicas && available == r.replicas:
+		m.msg = fmt.Sprintf("%s complete (%d replicas).", label, r.replicas)
+		m.rollout = nil
+	default:
+		m.msg = fmt.Sprintf("%s: %d/%d ready", label, ready, r.replicas)
+	}
+}
+
+func (m model) scaleConfirmView() string {
+	p := m.scale
+	explain := ""
+	if p.replicas == 0 {
+		explain = "\n\n" + lipgloss.NewStyle().Foreground(cRed).Render("All of its pods will be terminated.")
+	}
+	box := modalStyle.BorderForeground(cOrange).Render(fmt.Sprintf("%s\n\nConfirm scale of:\n%s\nfrom %d to %d replicas%s\n\n%s / %s", lipgloss.NewStyle().Foreground(cOrange).Bold(true).Render("[!] SCALE"), lipgloss.NewStyle().Foreground(cSecondary).Render(p.owner.String()), p.current, p.replicas, explain, lipgloss.NewStyle().Foreground(cGreen).Bold(true).Render("[y] Confirm"), lipgloss.NewStyle().Foreground(cDim).Render("[n] Cancel")))
+	return strings.Repeat("\n", m.height/3) + lipgloss.PlaceHorizontal(m.width, lipgloss.Center, box)
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// --- VIEWER SEARCH ---
+// contentSearch is the "/" search shared by the logs, YAML and diagnosis
+// viewports. Matching runs on the ANSI-stripped text of each line.
+type contentSearch struct {
+	query   string
+	regex   bool // Otherwise the query is literal
+	filter  bool // Hide lines without a match, like grep
+	re      *regexp.Regexp
+	matches []int // Displayed line of each match, once per occurrence
+	current int   // Index into matches
+}
+
+var (
+	matchStyle        = lipgloss.NewStyle().Foreground(cBg).Background(cYellow)
+	currentMatchStyle = lipgloss.NewStyle().Foreground(cBg).Background(cOrange).Bold(true)
+)
+
+// compile builds the matcher. Literal queries are case-insensitive unless
+// they contain an upper-case letter.
+func (f *contentSearch) compile() error {
+	f.re = nil
+	if f.query == "" {
+		return nil
+	}
+	expr := f.query
+	if !f.regex {
+		expr = regexp.QuoteMeta(expr)
+		if strings.ToLower(expr) == expr {
+			expr = "(?i)" + expr
+		}
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return err
+	}
+	f.re = re
+	return nil
+}
+
+// viewContent is the text of the active viewer before search is applied.
+func (m model) viewContent() string {
+	switch m.state {
+	case viewLogs, viewLogFields:
+		return m.renderLogs()
+	case viewDiagnosis:
+		return m.diagContent
+	case viewYaml:
+		return m.renderYamlView()
+	case viewApplyConfirm:
+		return m.editContent()
+	case viewRollbackConfirm:
+		if m.history.diff == "" {
+			return "No differences."
+		}
+		return colorDiff(m.history.diff)
+	}
+	return ""
+}
+
+// refreshViewport re-renders the active viewer, applying highlights and the
+// line filter. Matching runs on the stripped text; the highlight is laid
+// over the styled line.
+func (m *model) refreshViewport() {
+	f := &m.find
+	f.matches = nil
+	lines := strings.Split(m.viewContent(), "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if f.re == nil {
+			out = append(out, line)
+			continue
+		}
+		plain := ansi.Strip(line)
+		var locs [][]int
+		for _, loc := range f.re.FindAllStringIndex(plain, -1) {
+			if loc[0] != loc[1] {
+				locs = append(locs, loc)
+			}
+		}
+		if len(locs) == 0 {
+			if !f.filter {
+				out = append(out, line)
+			}
+			continue
+		}
+		first := len(f.matches)
+		for range locs {
+			f.matches = append(f.matches, len(out))
+		}
+		out = append(out, highlight(line, locs, f.current-first))
+	}
+	if f.current >= len(f.matches) {
+		f.current = 0
+	}
+	m.viewport.SetContent(strings.Join(out, "\n"))
+	if m.state == viewLogs && m.logFollow {
+		m.viewport.GotoBottom()
+	}
+}
+
+// highlight marks the byte ranges locs, given in the stripped text, on the
+// styled line. current is the index of the current match among locs.
+// Escapes inside a match are dropped so the highlight stays whole; after
+// it the line's own style is restored.
+func highlight(line string, locs [][]int, current int) string {
+	var b strings.Builder
+	active := "" // SGR sequences in effect at this point of the line
+	open := ""   // Escape that starts the current highlight
+	pos, li, in := 0, 0, false
+	for i := 0; i < len(line); {
+		if line[i] == '\x1b' && i+1 < len(line) && line[i+1] == '[' {
+			j := i + 2
+			for j < len(line) && (line[j] < 0x40 || line[j] > 0x7e) {
+				j++
+			}
+			j = min(j+1, len(line))
+			seq := line[i:j]
+			switch {
+			case seq == "\x1b[0m" || seq == "\x1b[m":
+				active = ""
+			case strings.HasSuffix(seq, "m"):
+				active += seq
+			}
+			if !in {
+				b.WriteString(seq)
+			}
+			i = j
+			continue
+		}
+		if !in && li < len(locs) && pos == locs[li][0] {
+			style := matchStyle
+			if li == current {
+				style = currentMatchStyle
+			}
+			open, _, _ = strings.Cut(style.Render("\x00"), "\x00")
+			b.WriteString(open)
+			in = true
+		}
+		b.WriteByte(line[i])
+		i++
+		pos++
+		if in && pos == locs[li][1] {
+			if open != "" {
+				b.WriteString("\x1b[0m" + active)
+			}
+			in = false
+			li++
+		}
+	}
+	return b.String()
+}
+
+// jumpToMatch scrolls to the current match and, in viewLogs, pauses follow.
+func (m *model) jumpToMatch() {
+	f := &m.find
+	if len(f.matches) == 0 {
+		return
+	}
+	m.refreshViewport()
+	m.viewport.SetYOffset(f.matches[f.current] - m.viewport.Height/3)
+	m.logFollow = false
+}
+
+// viewerKey handles the keys shared by the viewers: search and save. It
+// reports whether the key was consumed.
+func (m *model) viewerKey(key string) (bool, tea.Cmd) {
+	f := &m.find
+	switch key {
+	case "w":
+		m.openPrompt("save", "save as", m.saveFileName())
+		return true, textinput.Blink
+	case "/":
+		m.openPrompt("find", "search", f.query)
+		return true, textinput.Blink
+	case "n", "N":
+		if len(f.matches) == 0 {
+			return f.query != "", nil
+		}
+		if key == "n" {
+			f.current = (f.current + 1) % len(f.matches)
+		} else {
+			f.current = (f.current - 1 + len(f.matches)) % len(f.matches)
+		}
+		m.jumpToMatch()
+		return true, nil
+	case "R":
+		f.regex = !f.regex
+		if err := f.compile(); err != nil {
+			m.msg = fmt.Sprintf("Invalid regex: %v", err)
+		}
+		m.refreshViewport()
+		return true, nil
+	case "&":
+		f.filter = !f.filter
+		f.current = 0
+		m.refreshViewport()
+		return true, nil
+	case "esc":
+		if f.query == "" && !f.filter {
+			return false, nil
+		}
+		m.find = contentSearch{regex: f.regex}
+		m.refreshViewport()
+		return true, nil
+	}
+	return false, nil
+}
+
+// setSearch applies a query from the prompt and jumps to the first match
+// at or below the current scroll position.
+func (m *model) setSearch(query string) {
+	f := &m.find
+	f.query = query
+	f.current = 0
+	if err := f.compile(); err != nil {
+		m.msg = fmt.Sprintf("Invalid regex: %v", err)
+		m.refreshViewport()
+		return
+	}
+	m.refreshViewport()
+	if f.re == nil {
+		return
+	}
+	if len(f.matches) == 0 {
+		m.msg = fmt.Sprintf("No match for %q", query)
+		return
+	}
+	for i, line := range f.matches {
+		if line >= m.viewport.YOffset {
+			f.current = i
+			break
+		}
+	}
+	m.jumpToMatch()
+}
+
+func (m model) searchStatus() string {
+	f := m.find
+	var parts []string
+	if f.query != "" {
+		pos := 0
+		if len(f.matches) > 0 {
+			pos = f.current + 1
+		}
+		parts = append(parts, fmt.Sprintf("/%s [%d/%d]", f.query, pos, len(f.matches)))
+	}
+	if f.regex {
+		parts = append(parts, "regex")
+	}
+	if f.filter {
+		parts = append(parts, "filter")
+	}
+	return strings.Join(parts, "  ")
+}
+
+// viewerFooter is the bottom bar of the logs, YAML and diagnosis views.
+func (m model) viewerFooter(keys string) string {
+	if m.promptAction != "" {
+		return m.promptView()
+	}
+	if keys != "" {
+		keys += "  "
+	}
+	footer := footerStyle.Render("  " + keys + "[/] Find  [n/N] Next/Prev  [R] Regex  [&] Filter  [w] Save  [Esc] Back")
+	if s := m.searchStatus(); s != "" {
+		footer += "  " + searchStyle.Render(s)
+	}
+	return footer + "\n" + lipgloss.NewStyle().Foreground(cPrimary).Padding(0, 2).Render(m.msg)
+}
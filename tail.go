@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// --- MULTI-POD TAIL ---
+// tailSet streams every container of every pod matching a selector into one
+// logStream. It is re-synced against the informer cache on pod events, so
+// pods that come and go during a rollout are picked up.
+type tailSet struct {
+	ctx       context.Context
+	label     string // What is tailed, e.g. "Deployment/web" or "app=web"
+	namespace string // "" for all namespaces
+	selector  labels.Selector
+	pods      map[string]bool        // Marked ns/name set; overrides selector when set
+	streams   map[string]*tailStream // Keyed ns/pod/container; owned by Update
+	primed    bool                   // After the first sync, new containers are read from the top
+}
+
+type tailStream struct {
+	containerID string // A restarted container gets a new ID and a new stream
+	cancel      context.CancelFunc
+}
+
+type tailTargetMsg struct {
+	pod PodInfo
+	ref workloadRef
+	err error
+}
+
+var tailPalette = []lipgloss.Color{cPrimary, cGreen, cOrange, cCyan, cYellow, "#B48EAD", "#88C0D0", "#D08770", "#A3BE8C", "#EC407A"}
+
+// tailTag is the colored "pod container" prefix of each tailed line. The
+// color is derived from the pod name so it stays stable across restarts.
+func tailTag(pod, container string) string {
+	h := fnv.New32a()
+	h.Write([]byte(pod))
+	color := tailPalette[h.Sum32()%uint32(len(tailPalette))]
+	return lipgloss.NewStyle().Foreground(color).Render(truncate(pod, 40)+" "+container) + " "
+}
+
+// resolveTailTarget looks up the selected pod's controller to tail all of
+// its pods.
+func resolveTailTarget(c *kubernetes.Clientset, pod PodInfo, raw *corev1.Pod) tea.Cmd {
+	return func() tea.Msg {
+		if raw == nil {
+			return tailTargetMsg{pod: pod, err: fmt.Errorf("%s is gone", pod.Name)}
+		}
+		ref, err := resolveOwner(c, raw)
+		return tailTargetMsg{pod: pod, ref: ref, err: err}
+	}
+}
+
+// startTail cancels any running stream and tails every container matching
+// selector, or of the given pods.
+func (m *model) startTail(namespace, label string, selector labels.Selector, pods map[string]bool) tea.Cmd {
+	m.stopLogs()
+	m.logSeq++
+	ctx, cancel := context.WithCancel(context.Background())
+	m.logStream = &logStream{id: m.logSeq, cancel: cancel, done: ctx.Done(), lines: make(chan logLine, 1024), tail: &tailSet{
+		ctx: ctx, label: label, namespace: namespace, selector: selector, pods: pods, streams: make(map[string]*tailStream),
+	}}
+	m.logContainer = ""
+	m.logPrevious = false
+	m.resetLogBuffer()
+	m.syncTail()
+	return m.logStream.waitForLogLines()
+}
+
+// syncTail starts streams for new containers and stops those whose pods
+// no longer match.
+func (m *model) syncTail() {
+	t, lines, client := m.logStream.tail, m.logStream.lines, m.client
+	seen := make(map[string]bool)
+	for _, p := range m.watcher.listPods() {
+		if t.pods != nil {
+			if !t.pods[p.Namespace+"/"+p.Name] {
+				continue
+			}
+		} else if (t.namespace != "" && p.Namespace != t.namespace) || !t.selector.Matches(labels.Set(p.Labels)) {
+			continue
+		}
+		for _, cs := range p.Status.ContainerStatuses {
+			// Waiting containers have no log to read yet
+			if cs.ContainerID == "" || cs.State.Waiting != nil {
+				continue
+			}
+			key := p.Namespace + "/" + p.Name + "/" + cs.Name
+			seen[key] = true
+			if s, ok := t.streams[key]; ok {
+				if s.containerID == cs.ContainerID {
+					continue
+				}
+				s.cancel()
+			}
+			opts := &corev1.PodLogOptions{Container: cs.Name, Follow: true}
+			if !t.primed {
+				opts.TailLines = func(i int64) *int64 { return &i }(10)
+			}
+			ctx, cancel := context.WithCancel(t.ctx)
+			t.streams[key] = &tailStream{containerID: cs.ContainerID, cancel: cancel}
+			go func(ns, pod, container string) {
+				tag := tailTag(pod, container)
+				if err := pumpLogs(ctx, client, ns, pod, opts, tag, lines); err != nil {
+					select {
+					case lines <- logLine{tag: tag, text: lipgloss.NewStyle().Foreground(cDim).Render(fmt.Sprintf("stream error: %v", err))}:
+					case <-ctx.Done():
+					}
+				}
+			}(p.Namespace, p.Name, cs.Name)
+		}
+	}
+	for key, s := range t.streams {
+		if !seen[key] {
+			s.cancel()
+			delete(t.streams, key)
+		}
+	}
+	t.primed = true
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// --- WORKLOADS ---
+type WorkloadInfo struct {
+	Kind      string // Deployment, StatefulSet or DaemonSet
+	Namespace string
+	Name      string
+	UID       types.UID
+	Desired   int32
+	Ready     int32
+	UpToDate  int32
+	Available int32
+	Images    string
+	Age       string
+	Created   time.Time
+	Rollout   string // Same wording as `kubectl rollout status`, shortened
+	Settled   bool   // Rollout complete and every replica available
+
+	ObservedGeneration int64
+}
+
+func containerImages(spec corev1.PodSpec) string {
+	var images []string
+	for _, c := range spec.Containers {
+		images = append(images, c.Image)
+	}
+	return strings.Join(images, ",")
+}
+
+func workloadFromDeployment(d *appsv1.Deployment) WorkloadInfo {
+	w := WorkloadInfo{Kind: "Deployment", Namespace: d.Namespace, Name: d.Name, UID: d.UID, Created: d.CreationTimestamp.Time, ObservedGeneration: d.Status.ObservedGeneration,
+		Desired: 1, Ready: d.Status.ReadyReplicas, UpToDate: d.Status.UpdatedReplicas, Available: d.Status.AvailableReplicas,
+		Images: containerImages(d.Spec.Template.Spec)}
+	if d.Spec.Replicas != nil {
+		w.Desired = *d.Spec.Replicas
+	}
+	stalled := false
+	for _, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Reason == "ProgressDeadlineExceeded" {
+			stalled = true
+		}
+	}
+	switch {
+	case d.Spec.Paused:
+		w.Rollout = "Paused"
+	case d.Generation > d.Status.ObservedGeneration:
+		w.Rollout = "Waiting for spec update"
+	case stalled:
+		w.Rollout = "Stalled (deadline exceeded)"
+	case d.Status.UpdatedReplicas < w.Desired:
+		w.Rollout = fmt.Sprintf("Rolling %d/%d updated", d.Status.UpdatedReplicas, w.Desired)
+	case d.Status.Replicas > d.Status.UpdatedReplicas:
+		w.Rollout = fmt.Sprintf("Terminating %d old", d.Status.Replicas-d.Status.UpdatedReplicas)
+	case d.Status.AvailableReplicas < d.Status.UpdatedReplicas:
+		w.Rollout = fmt.Sprintf("Waiting %d/%d available", d.Status.AvailableReplicas, d.Status.UpdatedReplicas)
+	default:
+		w.Rollout, w.Settled = "Complete", true
+	}
+	return w
+}
+
+func workloadFromStatefulSet(s *appsv1.StatefulSet) WorkloadInfo {
+	w := WorkloadInfo{Kind: "StatefulSet", Namespace: s.Namespace, Name: s.Name, UID: s.UID, Created: s.CreationTimestamp.Time, ObservedGeneration: s.Status.ObservedGeneration,
+		Desired: 1, Ready: s.Status.ReadyReplicas, UpToDate: s.Status.UpdatedReplicas, Available: s.Status.AvailableReplicas,
+		Images: containerImages(s.Spec.Template.Spec)}
+	if s.Spec.Replicas != nil {
+		w.Desired = *s.Spec.Replicas
+	}
+	partition := int32(0)
+	if r := s.Spec.UpdateStrategy.RollingUpdate; r != nil && r.Partition != nil {
+		partition = *r.Partition
+	}
+	switch {
+	case s.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType:
+		w.Rollout, w.Settled = "OnDelete", s.Status.ReadyReplicas >= w.Desired
+	case s.Generation > s.Status.ObservedGeneration:
+		w.Rollout = "Waiting for spec update"
+	case s.Status.ReadyReplicas < w.Desired:
+		w.Rollout = fmt.Sprintf("Waiting %d/%d ready", s.Status.ReadyReplicas, w.Desired)
+	case partition > 0 && s.Status.UpdatedReplicas < w.Desired-partition:
+		w.Rollout = fmt.Sprintf("Partitioned %d/%d updated", s.Status.UpdatedReplicas, w.Desired-partition)
+	case partition == 0 && s.Status.UpdateRevision != s.Status.CurrentRevision:
+		w.Rollout = fmt.Sprintf("Rolling %d/%d updated", s.Status.UpdatedReplicas, w.Desired)
+	default:
+		w.Rollout, w.Settled = "Complete", true
+	}
+	return w
+}
+
+func workloadFromDaemonSet(d *appsv1.DaemonSet) WorkloadInfo {
+	w := WorkloadInfo{Kind: "DaemonSet", Namespace: d.Namespace, Name: d.Name, UID: d.UID, Created: d.CreationTimestamp.Time, ObservedGeneration: d.Status.ObservedGeneration,
+		Desired: d.Status.DesiredNumberScheduled, Ready: d.Status.NumberReady, UpToDate: d.Status.UpdatedNumberScheduled, Available: d.Status.NumberAvailable,
+		Images: containerImages(d.Spec.Template.Spec)}
+	switch {
+	case d.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType:
+		w.Rollout, w.Settled = "OnDelete", d.Status.NumberAvailable >= w.Desired
+	case d.Generation > d.Status.ObservedGeneration:
+		w.Rollout = "Waiting for spec update"
+	case d.Status.UpdatedNumberScheduled < w.Desired:
+		w.Rollout = fmt.Sprintf("Rolling %d/%d updated", d.Status.UpdatedNumberScheduled, w.Desired)
+	case d.Status.NumberAvailable < w.Desired:
+		w.Rollout = fmt.Sprintf("Waiting %d/%d available", d.Status.NumberAvailable, w.Desired)
+	default:
+		w.Rollout, w.Settled = "Complete", true
+	}
+	return w
+}
+
+func (m *model) loadWorkloads() {
+	m.workloads = m.workloads[:0]
+	for _, d := range m.watcher.listDeployments() {
+		m.workloads = append(m.workloads, workloadFromDeployment(d))
+	}
+	for _, s := range m.watcher.listStatefulSets() {
+		m.workloads = append(m.workloads, workloadFromStatefulSet(s))
+	}
+	for _, d := range m.watcher.listDaemonSets() {
+		m.workloads = append(m.workloads, workloadFromDaemonSet(d))
+	}
+	for i := range m.workloads {
+		m.workloads[i].Age = shortAge(time.Since(m.workloads[i].Created))
+	}
+}
+
+// filterWorkloads applies the pod list's namespace, issues and search
+// filters. Unsettled workloads sort first, like unhealthy pods.
+func (m *model) filterWorkloads() {
+	var target []WorkloadInfo
+	selectedNs := m.namespaces[m.currentNsIdx]
+	searchTerm := strings.ToLower(m.textInput.Value())
+	for _, w := range m.workloads {
+		if selectedNs != "ALL" && w.Namespace != selectedNs {
+			continue
+		}
+		if m.showIssues && w.Settled && w.Ready >= w.Desired {
+			continue
+		}
+		if searchTerm != "" && !strings.Contains(strings.ToLower(w.Name), searchTerm) {
+			continue
+		}
+		target = append(target, w)
+	}
+	sort.Slice(target, func(i, j int) bool {
+		if target[i].Settled != target[j].Settled {
+			return !target[i].Settled
+		}
+		if target[i].Kind != target[j].Kind {
+			return target[i].Kind < target[j].Kind
+		}
+		return target[i].Name < target[j].Name
+	})
+	m.filteredWorkloads = target
+	if m.wlCursor >= len(target) {
+		m.wlCursor = max(len(target)-1, 0)
+	}
+}
+
+func (m *model) cycleNamespace() {
+	if len(m.namespaces) > 1 {
+		m.currentNsIdx = (m.currentNsIdx + 1) % len(m.namespaces)
+		m.cursor, m.wlCursor = 0, 0
+		m.sort = sortDefault // Reset sort on NS change
+		m.filterPods()
+		m.filterWorkloads()
+	}
+}
+
+func (m model) updateWorkloads(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m.quit()
+	case "esc", "W":
+		m.state = viewList
+	case "up", "k":
+		if m.wlCursor > 0 {
+			m.wlCursor--
+		}
+	case "down", "j":
+		if m.wlCursor < len(m.filteredWorkloads)-1 {
+			m.wlCursor++
+		}
+	case "/":
+		m.searchActive = true
+		m.textInput.Focus()
+		return m, textinput.Blink
+	case "n":
+		m.cycleNamespace()
+	case "tab":
+		m.showIssues = !m.showIssues
+		m.wlCursor = 0
+		m.filterPods()
+		m.filterWorkloads()
+		if m.showIssues {
+			m.msg = "Filter: Issues Only"
+		} else {
+			m.msg = "Filter: Showing All"
+		}
+	case "S":
+		if len(m.filteredWorkloads) > 0 {
+			w := m.filteredWorkloads[m.wlCursor]
+			if w.Kind == "DaemonSet" {
+				m.msg = "DaemonSets run one pod per node and cannot be scaled."
+				return m, nil
+			}
+			m.msg = fmt.Sprintf("Reading scale of %s/%s...", w.Kind, w.Name)
+			m.scaleReturn = m.state
+			return m, resolveScaleTarget(m.client, nil, workloadRef{Kind: w.Kind, Namespace: w.Namespace, Name: w.Name})
+		}
+	case "H":
+		if len(m.filteredWorkloads) > 0 {
+			w := m.filteredWorkloads[m.wlCursor]
+			if w.Kind != "Deployment" {
+				m.msg = "Only Deployments keep a rollout history."
+				return m, nil
+			}
+			m.openHistory(w.Namespace, w.Name)
+		}
+	case "enter":
+		if len(m.filteredWorkloads) > 0 {
+			w := m.filteredWorkloads[m.wlCursor]
+			m.ownerFilter, m.nodeFilter = &w, ""
+			m.state = viewList
+			m.cursor = 0
+			m.filterPods()
+			m.msg = fmt.Sprintf("Pods of %s/%s", w.Kind, w.Name)
+		}
+	}
+	return m, nil
+}
+
+func (m model) workloadsView() string {
+	currentNs := m.namespaces[m.currentNsIdx]
+	contextInfo := contextStyle.Render(fmt.Sprintf("  Namespace: %s  |  WORKLOADS: %d", currentNs, len(m.filteredWorkloads)))
+	if len(m.filteredWorkloads) == 0 {
+		contextInfo = lipgloss.NewStyle().Foreground(cDim).Render(fmt.Sprintf("  NS: %s  |  No workloads found.", currentNs))
+	}
+
+	var b bytes.Buffer
+	tw := tabwriter.NewWriter(&b, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n", "NAMESPACE", "KIND", "NAME", "DESIRED", "READY", "UP-TO-DATE", "AVAILABLE", "IMAGES", "AGE", "ROLLOUT")
+	start, end := m.calculatePagination(m.wlCursor, len(m.filteredWorkloads))
+	for i := start; i < end; i++ {
+		w := m.filteredWorkloads[i]
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%d\t%d\t%d\t%d\t%s\t%s\t%s\t\n",
+			truncate(w.Namespace, 25), w.Kind, truncate(w.Name, 45), w.Desired, w.Ready, w.UpToDate, w.Available, truncate(w.Images, 40), w.Age, w.Rollout)
+	}
+	tw.Flush()
+
+	lines := strings.Split(b.String(), "\n")
+	rows := colHeadStyle.Render(lines[0]) + "\n"
+	for i := start; i < end && i-start+1 < len(lines); i++ {
+		line := lines[i-start+1]
+		w := m.filteredWorkloads[i]
+		style := lipgloss.NewStyle().Foreground(cSecondary)
+		switch {
+		case i == m.wlCursor:
+			style = selectedRowStyle
+			if len(line) > 2 {
+				line = "| " + line[2:]
+			}
+		case w.Ready < w.Desired && w.Settled, strings.HasPrefix(w.Rollout, "Stalled"):
+			style = style.Foreground(cRed)
+		case !w.Settled:
+			style = style.Foreground(cOrange)
+		}
+		rows += style.Render(line) + "\n"
+	}
+
+	if m.promptAction != "" {
+		return "\n" + m.topBar() + "\n\n" + contextInfo + "\n\n" + rows + "\n" + m.promptView() + "\n"
+	}
+	if m.searchActive {
+		return "\n" + m.topBar() + "\n\n" + contextInfo + "\n\n" + rows + "\n" + searchStyle.Render("SEARCH: "+m.textInput.View()) + "\n"
+	}
+	help := footerStyle.Render(fmt.Sprintf("\n  [Enter] Pods  [S] Scale  [H] History  [Tab] Filter (%v)  [n] NS  [/] Search  [Esc] Back to Pods  [q] Quit", m.showIssues))
+	status := lipgloss.NewStyle().Foreground(cPrimary).Padding(0, 2).Render(m.msg)
+	return "\n" + m.topBar() + "\n\n" + contextInfo + "\n\n" + rows + "\n" + help + "\n" + status
+}
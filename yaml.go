@@ -0,0 +1,349 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+// --- OBJECT VIEWER ---
+// The object is fetched once; the managedFields/status/format toggles only
+// re-serialize the cached copy.
+type yamlOptions struct {
+	managedFields bool
+	status        bool
+	json          bool
+}
+
+type yamlMsg struct {
+	id  int // Responses for an earlier fetch are dropped
+	obj map[string]interface{}
+	err error
+}
+
+func fetchYaml(c *kubernetes.Clientset, namespace, pod string, id int) tea.Cmd {
+	return func() tea.Msg {
+		p, err := c.CoreV1().Pods(namespace).Get(context.TODO(), pod, metav1.GetOptions{})
+		if err != nil {
+			return yamlMsg{id: id, err: err}
+		}
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(p)
+		if err != nil {
+			return yamlMsg{id: id, err: err}
+		}
+		// Typed Gets come back without TypeMeta
+		obj["apiVersion"], obj["kind"] = "v1", "Pod"
+		return yamlMsg{id: id, obj: obj}
+	}
+}
+
+// renderYaml serializes the cached object with the current toggles.
+func (m *model) renderYaml() {
+	if m.yamlObj == nil {
+		return
+	}
+	obj := make(map[string]interface{}, len(m.yamlObj))
+	for k, v := range m.yamlObj {
+		obj[k] = v
+	}
+	if meta, ok := obj["metadata"].(map[string]interface{}); ok && !m.yamlOpts.managedFields {
+		trimmed := make(map[string]interface{}, len(meta))
+		for k, v := range meta {
+			if k != "managedFields" {
+				trimmed[k] = v
+			}
+		}
+		obj["metadata"] = trimmed
+	}
+	if !m.yamlOpts.status {
+		delete(obj, "status")
+	}
+
+	var out []byte
+	var err error
+	if m.yamlOpts.json {
+		out, err = json.MarshalIndent(obj, "", "  ")
+	} else {
+		out, err = yaml.Marshal(obj)
+	}
+	if err != nil {
+		m.yamlContent = "Error: " + err.Error()
+	} else {
+		m.yamlContent = strings.TrimRight(string(out), "\n")
+	}
+	m.refreshViewport()
+}
+
+func (m model) yamlExt() string {
+	if m.yamlOpts.json {
+		return ".json"
+	}
+	return ".yaml"
+}
+
+func (o yamlOptions) label() string {
+	parts := []string{"yaml"}
+	if o.json {
+		parts[0] = "json"
+	}
+	if !o.managedFields {
+		parts = append(parts, "-managedFields")
+	}
+	if !o.status {
+		parts = append(parts, "-status")
+	}
+	return strings.Join(parts, " ")
+}
+
+// --- HIGHLIGHTING & FOLDING ---
+var (
+	yamlKeyStyle     = lipgloss.NewStyle().Foreground(cCyan)
+	yamlStringStyle  = lipgloss.NewStyle().Foreground(cGreen)
+	yamlNumberStyle  = lipgloss.NewStyle().Foreground(cOrange)
+	yamlLiteralStyle = lipgloss.NewStyle().Foreground(cYellow) // true, false, null
+	yamlCommentStyle = lipgloss.NewStyle().Foreground(cDim).Italic(true)
+
+	yamlNumberRe = regexp.MustCompile(`^-?(\d+\.?\d*|\.\d+)([eE][-+]?\d+)?$`)
+)
+
+// yamlLine is one line of the serialized object. Lines that open blocks (a
+// key with a nested value, a list item, or both as in "- env:") carry a
+// node per block; those nodes can fold.
+type yamlLine struct {
+	text   string
+	nodes  []yamlNode
+	scalar bool // Inside a literal or folded block scalar
+}
+
+type yamlNode struct {
+	path string // e.g. spec.containers[0].env
+	end  int    // First line after the block; 0 if it has no children
+}
+
+type yamlFrame struct {
+	col   int
+	path  string
+	line  int
+	node  int // Index into lines[line].nodes
+	items int // Next sequence index under this key
+}
+
+// parseYamlLines assigns paths and block extents to the output of
+// sigs.k8s.io/yaml, which uses two-space indents and unindented sequences.
+func parseYamlLines(content string) []yamlLine {
+	raw := strings.Split(content, "\n")
+	lines := make([]yamlLine, len(raw))
+	var stack []yamlFrame
+	closeFrames := func(keep func(yamlFrame) bool, at int) {
+		for len(stack) > 0 && !keep(stack[len(stack)-1]) {
+			top := stack[len(stack)-1]
+			if at > top.line+1 {
+				lines[top.line].nodes[top.node].end = at
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	scalarCol := -1
+	for i, text := range raw {
+		trimmed := strings.TrimLeft(text, " ")
+		col := len(text) - len(trimmed)
+		lines[i] = yamlLine{text: text}
+		if trimmed == "" {
+			continue
+		}
+		if scalarCol >= 0 {
+			if col > scalarCol {
+				lines[i].scalar = true
+				continue
+			}
+			scalarCol = -1
+		}
+		if strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		item := trimmed == "-" || strings.HasPrefix(trimmed, "- ")
+		// Unindented sequences sit at the same column as their key
+		closeFrames(func(f yamlFrame) bool {
+			return f.col < col || (item && f.col == col && !strings.HasSuffix(f.path, "]"))
+		}, i)
+		parent := ""
+		if len(stack) > 0 {
+			parent = stack[len(stack)-1].path
+		}
+		rest := trimmed
+		if item {
+			idx := 0
+			if len(stack) > 0 {
+				idx = stack[len(stack)-1].items
+				stack[len(stack)-1].items++
+			}
+			parent = fmt.Sprintf("%s[%d]", parent, idx)
+			lines[i].nodes = append(lines[i].nodes, yamlNode{path: parent})
+			stack = append(stack, yamlFrame{col: col, path: parent, line: i, node: len(lines[i].nodes) - 1})
+			rest = strings.TrimPrefix(strings.TrimPrefix(trimmed, "-"), " ")
+			col += 2
+		}
+		key, value, isKey := splitYamlKey(rest)
+		if !isKey {
+			continue
+		}
+		path := key
+		if parent != "" {
+			path = parent + "." + key
+		}
+		switch {
+		case value == "":
+			lines[i].nodes = append(lines[i].nodes, yamlNode{path: path})
+			stack = append(stack, yamlFrame{col: col, path: path, line: i, node: len(lines[i].nodes) - 1})
+		case strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">"):
+			scalarCol = col
+		}
+	}
+	closeFrames(func(yamlFrame) bool { return false }, len(raw))
+	return lines
+}
+
+// splitYamlKey splits "key: value" and reports whether s is a mapping
+// entry at all.
+func splitYamlKey(s string) (string, string, bool) {
+	if strings.HasPrefix(s, "\"") || strings.HasPrefix(s, "'") {
+		q := s[:1]
+		end := strings.Index(s[1:], q+":")
+		if end < 0 {
+			return "", "", false
+		}
+		return s[:end+2], strings.TrimSpace(s[end+3:]), true
+	}
+	if strings.HasSuffix(s, ":") {
+		return s[:len(s)-1], "", true
+	}
+	if i := strings.Index(s, ": "); i >= 0 {
+		return s[:i], strings.TrimSpace(s[i+2:]), true
+	}
+	return "", "", false
+}
+
+func highlightYamlValue(v string) string {
+	switch {
+	case v == "":
+		return ""
+	case strings.HasPrefix(v, "#"):
+		return yamlCommentStyle.Render(v)
+	case v == "true" || v == "false" || v == "null" || v == "~" || v == "{}" || v == "[]":
+		return yamlLiteralStyle.Render(v)
+	case yamlNumberRe.MatchString(v):
+		return yamlNumberStyle.Render(v)
+	}
+	return yamlStringStyle.Render(v)
+}
+
+func highlightYamlLine(l yamlLine) string {
+	trimmed := strings.TrimLeft(l.text, " ")
+	pad := l.text[:len(l.text)-len(trimmed)]
+	switch {
+	case trimmed == "":
+		return l.text
+	case l.scalar:
+		return pad + yamlStringStyle.Render(trimmed)
+	case strings.HasPrefix(trimmed, "#"):
+		return pad + yamlCommentStyle.Render(trimmed)
+	}
+	var b strings.Builder
+	b.WriteString(pad)
+	if trimmed == "-" || strings.HasPrefix(trimmed, "- ") {
+		b.WriteString(lipgloss.NewStyle().Foreground(cDim).Render("-"))
+		trimmed = strings.TrimPrefix(trimmed, "-")
+		if trimmed == "" {
+			return b.String()
+		}
+		b.WriteString(" ")
+		trimmed = trimmed[1:]
+	}
+	if key, value, ok := splitYamlKey(trimmed); ok {
+		b.WriteString(yamlKeyStyle.Render(key) + ":")
+		if value != "" {
+			b.WriteString(" " + highlightYamlValue(value))
+		}
+		return b.String()
+	}
+	b.WriteString(highlightYamlValue(trimmed))
+	return b.String()
+}
+
+// renderYamlView highlights yamlContent and collapses folded blocks. JSON
+// output is shown as serialized.
+func (m model) renderYamlView() string {
+	if m.yamlOpts.json || m.yamlObj == nil {
+		return m.yamlContent
+	}
+	lines := parseYamlLines(m.yamlContent)
+	out := make([]string, 0, len(lines))
+	for i := 0; i < len(lines); i++ {
+		l := lines[i]
+		end := 0
+		for _, n := range l.nodes {
+			if n.end > 0 && m.yamlFolds[n.path] {
+				end = n.end
+				break
+			}
+		}
+		if end > 0 {
+			out = append(out, highlightYamlLine(l)+lipgloss.NewStyle().Foreground(cDim).Render(fmt.Sprintf(" … %d lines", end-i-1)))
+			i = end - 1
+			continue
+		}
+		out = append(out, highlightYamlLine(l))
+	}
+	return strings.Join(out, "\n")
+}
+
+// toggleFold folds every block whose path matches pattern, where "*"
+// stands for any key or list index (spec.containers[*].env). If any match
+// is already folded, all matches are unfolded instead.
+func (m *model) toggleFold(pattern string) {
+	expr := regexp.QuoteMeta(strings.TrimSpace(pattern))
+	expr = strings.ReplaceAll(expr, `\*`, `[^.\[\]]+`)
+	re, err := regexp.Compile("^" + expr + "$")
+	if err != nil {
+		m.msg = fmt.Sprintf("Invalid path %q", pattern)
+		return
+	}
+	var matches []string
+	folded := false
+	for _, l := range parseYamlLines(m.yamlContent) {
+		for _, n := range l.nodes {
+			if n.end > 0 && re.MatchString(n.path) {
+				matches = append(matches, n.path)
+				folded = folded || m.yamlFolds[n.path]
+			}
+		}
+	}
+	if len(matches) == 0 {
+		m.msg = fmt.Sprintf("No block at %q", pattern)
+		return
+	}
+	for _, p := range matches {
+		if folded {
+			delete(m.yamlFolds, p)
+		} else {
+			m.yamlFolds[p] = true
+		}
+	}
+	if folded {
+		m.msg = fmt.Sprintf("Unfolded %d blocks", len(matches))
+	} else {
+		m.msg = fmt.Sprintf("Folded %d blocks", len(matches))
+	}
+	m.refreshViewport()
+}